@@ -0,0 +1,211 @@
+package validet
+
+import (
+	"archive/zip"
+	"errors"
+	"mime/multipart"
+	"path"
+	"strings"
+)
+
+// Archive restricts the files accepted by File to ZIP archives that are safe
+// to extract. The archive is inspected through its central directory with
+// archive/zip, nothing is decompressed. Files that aren't ZIP archives fail
+// with the "archive" rule.
+//
+// MaxEntries limits the number of entries, MaxSize their total uncompressed
+// size in bytes and MaxRatio the compression ratio of every entry, when they
+// are set, to stop zip bombs. The sizes are the ones declared by the
+// archive, which archive/zip enforces when the entries are extracted.
+// Entries with an absolute path or a ".." element are always rejected, and
+// Extensions, e.g. "pdf" or ".csv", restricts the extensions of the files
+// of the archive.
+type Archive struct {
+	MaxEntries int
+	MaxSize    int64
+	MaxRatio   float64
+	Extensions []string
+}
+
+func (s File) assertArchive(key string, value *multipart.FileHeader, bags *[]FieldError, option Options) error {
+	if s.Archive == nil {
+		return nil
+	}
+	entries, err := readArchive(value)
+	if err != nil {
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleArchive, Value: value},
+			RuleArchive,
+			s.Message.Archive,
+		)
+		return FileValidationError
+	}
+
+	var failed error
+	for _, assert := range []func(string, *multipart.FileHeader, []*zip.File, *[]FieldError) error{
+		s.assertArchiveEntries,
+		s.assertArchiveSize,
+		s.assertArchiveRatio,
+		s.assertArchivePaths,
+		s.assertArchiveExtensions,
+	} {
+		if err := assert(key, value, entries, bags); err != nil {
+			if option.AbortEarly {
+				return err
+			}
+			failed = err
+		}
+	}
+	return failed
+}
+
+func (s File) assertArchiveEntries(key string, value *multipart.FileHeader, entries []*zip.File, bags *[]FieldError) error {
+	if s.Archive.MaxEntries > 0 && len(entries) > s.Archive.MaxEntries {
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleArchiveEntries, Params: map[string]any{"max_entries": s.Archive.MaxEntries, "entries": len(entries)}, Value: value},
+			RuleArchiveEntries,
+			s.Message.ArchiveEntries,
+		)
+		return FileValidationError
+	}
+	return nil
+}
+
+func (s File) assertArchiveSize(key string, value *multipart.FileHeader, entries []*zip.File, bags *[]FieldError) error {
+	if s.Archive.MaxSize <= 0 {
+		return nil
+	}
+	var size uint64
+	for _, entry := range entries {
+		size += entry.UncompressedSize64
+		if size > uint64(s.Archive.MaxSize) {
+			appendErrorBags(
+				bags,
+				key,
+				FieldError{Rule: RuleArchiveSize, Params: map[string]any{"max_size": s.Archive.MaxSize}, Value: value},
+				RuleArchiveSize,
+				s.Message.ArchiveSize,
+			)
+			return FileValidationError
+		}
+	}
+	return nil
+}
+
+func (s File) assertArchiveRatio(key string, value *multipart.FileHeader, entries []*zip.File, bags *[]FieldError) error {
+	if s.Archive.MaxRatio <= 0 {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.UncompressedSize64 == 0 {
+			continue
+		}
+		// An empty compressed stream can't inflate to anything, so a
+		// compressed size of 0 is treated as 1 byte.
+		compressed := max(entry.CompressedSize64, 1)
+		if float64(entry.UncompressedSize64)/float64(compressed) > s.Archive.MaxRatio {
+			appendErrorBags(
+				bags,
+				key,
+				FieldError{Rule: RuleArchiveRatio, Params: map[string]any{"max_ratio": s.Archive.MaxRatio, "entry": entry.Name}, Value: value},
+				RuleArchiveRatio,
+				s.Message.ArchiveRatio,
+			)
+			return FileValidationError
+		}
+	}
+	return nil
+}
+
+func (s File) assertArchivePaths(key string, value *multipart.FileHeader, entries []*zip.File, bags *[]FieldError) error {
+	for _, entry := range entries {
+		if !safeArchivePath(entry.Name) {
+			appendErrorBags(
+				bags,
+				key,
+				FieldError{Rule: RuleArchivePath, Params: map[string]any{"entry": entry.Name}, Value: value},
+				RuleArchivePath,
+				s.Message.ArchivePath,
+			)
+			return FileValidationError
+		}
+	}
+	return nil
+}
+
+func (s File) assertArchiveExtensions(key string, value *multipart.FileHeader, entries []*zip.File, bags *[]FieldError) error {
+	if len(s.Archive.Extensions) == 0 {
+		return nil
+	}
+	allowed := make(map[string]bool, len(s.Archive.Extensions))
+	for _, ext := range s.Archive.Extensions {
+		allowed[archiveExtension(ext)] = true
+	}
+	for _, entry := range entries {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		if !allowed[archiveExtension(path.Ext(entry.Name))] {
+			appendErrorBags(
+				bags,
+				key,
+				FieldError{Rule: RuleArchiveExtensions, Params: map[string]any{"extensions": s.Archive.Extensions, "entry": entry.Name}, Value: value},
+				RuleArchiveExtensions,
+				s.Message.ArchiveExtensions,
+			)
+			return FileValidationError
+		}
+	}
+	return nil
+}
+
+func archiveExtension(ext string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(ext)), ".")
+}
+
+// safeArchivePath reports whether the entry would be extracted inside the
+// target directory, whatever the operating system.
+func safeArchivePath(name string) bool {
+	name = strings.ReplaceAll(name, `\`, "/")
+	if name == "" || strings.HasPrefix(name, "/") || (len(name) >= 2 && name[1] == ':') {
+		return false
+	}
+	for _, element := range strings.Split(name, "/") {
+		if element == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// readArchive lists the entries of the ZIP archive from its central
+// directory.
+func readArchive(header *multipart.FileHeader) ([]*zip.File, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader, err := zip.NewReader(file, header.Size)
+	// Insecure paths are reported by assertArchivePaths.
+	if err != nil && !errors.Is(err, zip.ErrInsecurePath) {
+		return nil, err
+	}
+	return reader.File, nil
+}
+
+func (s Archive) compile(path []string) error {
+	if s.MaxEntries < 0 || s.MaxSize < 0 || s.MaxRatio < 0 {
+		return builderError(path, errors.New("archive limits can't be negative"))
+	}
+	for _, ext := range s.Extensions {
+		if archiveExtension(ext) == "" {
+			return builderError(path, errors.New("archive extensions can't be empty"))
+		}
+	}
+	return nil
+}
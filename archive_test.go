@@ -0,0 +1,101 @@
+package validet
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_Archive(t *testing.T) {
+	archive := func(entries map[string][]byte) []byte {
+		var buf bytes.Buffer
+		writer := zip.NewWriter(&buf)
+		for name, content := range entries {
+			w, err := writer.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w.Write(content)
+		}
+		writer.Close()
+		return buf.Bytes()
+	}
+	bundle := fileHeader(t, "bundle.zip", "application/zip", archive(map[string][]byte{
+		"docs/":          nil,
+		"docs/guide.pdf": []byte("%PDF-1.4"),
+		"data.CSV":       []byte("a,b\n1,2\n"),
+	}))
+	bomb := fileHeader(t, "bomb.zip", "application/zip", archive(map[string][]byte{
+		"zeros.csv": make([]byte, 1<<20),
+	}))
+	traversal := fileHeader(t, "evil.zip", "application/zip", archive(map[string][]byte{
+		"../../etc/cron.d/job.csv": []byte("* * * * * root sh"),
+	}))
+
+	validate := func(rule Archive, value any) map[string][]string {
+		schema := NewSchema(DataObject{"bundle": value}, SchemaRules{"bundle": File{Archive: &rule}}, Options{})
+		bags, _ := schema.Validate()
+		return bags.Errors
+	}
+
+	t.Run("it should accept safe archives", func(t *testing.T) {
+		rule := Archive{MaxEntries: 3, MaxSize: 1 << 10, MaxRatio: 10, Extensions: []string{"pdf", ".csv"}}
+		if errors := validate(rule, bundle); len(errors) > 0 {
+			t.Errorf("Actual = %v, Expected no errors", errors)
+		}
+	})
+
+	t.Run("it should reject files that aren't ZIP archives", func(t *testing.T) {
+		errors := validate(Archive{}, fileHeader(t, "bundle.zip", "application/zip", []byte("PK not really")))
+		expected := map[string][]string{"bundle": {"bundle must be a ZIP archive"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should stop zip bombs", func(t *testing.T) {
+		errors := validate(Archive{MaxSize: 1 << 19, MaxRatio: 100}, bomb)
+		expected := map[string][]string{"bundle": {
+			"bundle uncompressed size must be at maximum 524288",
+			"bundle entry zeros.csv is compressed more than 100 times",
+		}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should check the entries", func(t *testing.T) {
+		errors := validate(Archive{MaxEntries: 2, Extensions: []string{"pdf"}}, bundle)
+		expected := map[string][]string{"bundle": {
+			"bundle must contain at most 2 entries",
+			"bundle entry data.CSV must be a file of type pdf",
+		}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should reject entries extracted outside the target", func(t *testing.T) {
+		errors := validate(Archive{}, traversal)
+		expected := map[string][]string{"bundle": {"bundle entry ../../etc/cron.d/job.csv has an unsafe path"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+		for name, expected := range map[string]bool{
+			"docs/guide.pdf": true, "a..b.txt": true, "/etc/passwd": false,
+			`..\windows\evil.dll`: false, "C:/evil.dll": false, "docs/../../x": false,
+		} {
+			if actual := safeArchivePath(name); actual != expected {
+				t.Errorf("%s: Actual = %v, Expected = %v", name, actual, expected)
+			}
+		}
+	})
+
+	t.Run("it should reject invalid archive options when compiling", func(t *testing.T) {
+		_, err := Compile(SchemaRules{"bundle": File{Archive: &Archive{MaxRatio: -1}}})
+		if err == nil || err.Error() != "validet: bundle: archive limits can't be negative" {
+			t.Errorf("Actual = %v", err)
+		}
+	})
+}
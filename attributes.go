@@ -0,0 +1,48 @@
+package validet
+
+import "strings"
+
+// Attributes maps field paths to human friendly names, e.g.
+// {"information.job.level": "job level"}. A "*" segment matches any single
+// segment, so "items.*.title" names the title of every SliceObject item.
+// Exact paths win over wildcard paths, and paths with fewer wildcards win
+// over paths with more. Attributes only change messages, ErrorBag keys stay
+// the dotted field paths.
+type Attributes map[string]string
+
+func (a Attributes) name(path []string, fallback string) string {
+	if len(a) == 0 {
+		return fallback
+	}
+	if name, ok := a[strings.Join(path, ".")]; ok {
+		return name
+	}
+	name, wildcards := fallback, -1
+	for pattern, display := range a {
+		n, ok := matchAttribute(strings.Split(pattern, "."), path)
+		if !ok {
+			continue
+		}
+		if wildcards == -1 || n < wildcards || (n == wildcards && display < name) {
+			name, wildcards = display, n
+		}
+	}
+	return name
+}
+
+func matchAttribute(pattern []string, path []string) (int, bool) {
+	if len(pattern) != len(path) {
+		return 0, false
+	}
+	wildcards := 0
+	for i, segment := range pattern {
+		if segment == "*" {
+			wildcards++
+			continue
+		}
+		if segment != path[i] {
+			return 0, false
+		}
+	}
+	return wildcards, true
+}
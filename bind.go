@@ -0,0 +1,270 @@
+package validet
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Bind validates the data against the schema and, when it passes, fills a
+// new T with the validated values only. Fields of T are matched by their
+// json tag names, like ValidateStruct. Numeric values are converted to the
+// numeric type of the target field as long as no precision is lost, and the
+// strings validated for time.Time fields, or fields of a type implementing
+// encoding.TextUnmarshaler, are parsed with UnmarshalText.
+//
+// The rules still check the values as they are in data. JSON decoded into a
+// DataObject holds every number as a float64, which fails the type check of
+// a Numeric[int]: use Numeric[float64] rules for int fields, or set
+// Options.Coerce to convert the numbers to the rule types.
+//
+// The error is either the validation error, returned with the ErrorBag, or
+// an error describing why the validated data could not be bound to T.
+func Bind[T any](data DataObject, schema SchemaRules, options Options) (T, ErrorBag, error) {
+	var target T
+	validated, bags, err := runValidated(&Validation{
+		data:    data,
+		schema:  schema,
+		options: options,
+	})
+	if err != nil {
+		return target, bags, err
+	}
+	if err := bindValue(reflect.ValueOf(&target).Elem(), validated, nil); err != nil {
+		return target, ErrorBag{}, err
+	}
+	return target, ErrorBag{}, nil
+}
+
+// BindError reports a validated value that could not be bound to the field
+// at Path. Err is the error of the UnmarshalText or UnmarshalJSON method of
+// the field type, if any.
+type BindError struct {
+	Path  []string
+	Value any
+	Type  reflect.Type
+	Err   error
+}
+
+func (e *BindError) Error() string {
+	path := strings.Join(e.Path, ".")
+	if path == "" {
+		path = "data"
+	}
+	message := fmt.Sprintf("validet: cannot bind %s of type %T into %s", path, e.Value, e.Type)
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+func bindValue(dst reflect.Value, src any, path []string) error {
+	if src == nil {
+		return nil
+	}
+	fail := &BindError{Path: path, Value: src, Type: dst.Type()}
+
+	if structLeaf(dst.Type()) {
+		if err := bindLeaf(dst, src); err != nil {
+			fail.Err = err
+			return fail
+		}
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return bindValue(dst.Elem(), src, path)
+	case reflect.Interface:
+		if reflect.TypeOf(src).AssignableTo(dst.Type()) {
+			dst.Set(reflect.ValueOf(src))
+			return nil
+		}
+		return fail
+	case reflect.Struct:
+		values, ok := src.(DataObject)
+		if !ok {
+			break
+		}
+		fields, err := structFields(dst.Type())
+		if err != nil {
+			fail.Err = err
+			return fail
+		}
+		for _, f := range fields {
+			value, ok := values[f.name]
+			if !ok {
+				continue
+			}
+			if err := bindValue(structFieldByIndex(dst, f.index), value, append(path[:len(path):len(path)], f.name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		values, ok := src.(DataObject)
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			break
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), len(values))
+		for k, value := range values {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := bindValue(elem, value, append(path[:len(path):len(path)], k)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(m)
+		return nil
+	case reflect.Slice:
+		values, ok := src.([]any)
+		if !ok {
+			break
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := bindValue(slice.Index(i), value, append(path[:len(path):len(path)], fmt.Sprint(i))); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := numericAsInt(src); ok && !dst.OverflowInt(i) {
+			dst.SetInt(i)
+			return nil
+		}
+		return fail
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, ok := numericAsUint(src); ok && !dst.OverflowUint(u) {
+			dst.SetUint(u)
+			return nil
+		}
+		return fail
+	case reflect.Float32, reflect.Float64:
+		if n, ok := numericAsFloat(src); ok && !dst.OverflowFloat(n) {
+			dst.SetFloat(n)
+			return nil
+		}
+		return fail
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+	if sv.Kind() == dst.Kind() && sv.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(sv.Convert(dst.Type()))
+		return nil
+	}
+	return fail
+}
+
+// bindLeaf binds the text form of a value, as ValidateStruct validates it,
+// into a structLeaf type such as time.Time.
+func bindLeaf(dst reflect.Value, src any) error {
+	if sv := reflect.ValueOf(src); sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+	ptr := reflect.New(dst.Type())
+	switch v := ptr.Interface().(type) {
+	case encoding.TextUnmarshaler:
+		text, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %T", src)
+		}
+		if err := v.UnmarshalText([]byte(text)); err != nil {
+			return err
+		}
+	case json.Unmarshaler:
+		source, err := json.Marshal(src)
+		if err != nil {
+			return err
+		}
+		if err := v.UnmarshalJSON(source); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%s has no unmarshal method", dst.Type())
+	}
+	dst.Set(ptr.Elem())
+	return nil
+}
+
+func structFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func numericAsFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+func numericAsInt(value any) (int64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
+
+func numericAsUint(value any) (uint64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return 0, false
+		}
+		return uint64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, false
+		}
+		return uint64(f), true
+	}
+	return 0, false
+}
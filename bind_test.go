@@ -0,0 +1,129 @@
+package validet
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type bindTestItem struct {
+	Title string `json:"title"`
+}
+
+type bindTestRequest struct {
+	Name  string         `json:"name"`
+	Age   int            `json:"age"`
+	Score uint32         `json:"score"`
+	Tags  []string       `json:"tags"`
+	Items []bindTestItem `json:"items"`
+	Extra string         `json:"extra"`
+}
+
+func Test_Bind(t *testing.T) {
+	rules := SchemaRules{
+		"name":  String{Required: true},
+		"age":   Numeric[float64]{Required: true},
+		"score": Numeric[float64]{},
+		"tags":  Slice[string]{},
+		"items": SliceObject{Item: SchemaObject{"title": String{Required: true}}},
+	}
+
+	t.Run("it should fill the struct with the validated values only", func(t *testing.T) {
+		request, _, err := Bind[bindTestRequest](DataObject{
+			"name":  "tono",
+			"age":   30.0,
+			"score": 7.0,
+			"tags":  []any{"a", "b"},
+			"items": []any{DataObject{"title": "pen", "color": "red"}},
+			"extra": "not validated",
+		}, rules, Options{})
+		if err != nil {
+			t.Fatalf("Actual = %v, Expected = %v", err, nil)
+		}
+		if request.Name != "tono" || request.Age != 30 || request.Score != 7 {
+			t.Errorf("Actual = %+v", request)
+		}
+		if len(request.Tags) != 2 || len(request.Items) != 1 || request.Items[0].Title != "pen" {
+			t.Errorf("Actual = %+v", request)
+		}
+		if request.Extra != "" {
+			t.Errorf("Actual = %v, Expected = %v", request.Extra, "")
+		}
+	})
+	t.Run("it should return the error bag when the validation fails", func(t *testing.T) {
+		_, bags, err := Bind[bindTestRequest](DataObject{"age": 30.0}, rules, Options{})
+		if err == nil {
+			t.Fatalf("Actual = %v, Expected an error", err)
+		}
+		if _, ok := bags.Errors["name"]; !ok {
+			t.Errorf("Actual = %v, Expected key = name", bags.Errors)
+		}
+	})
+	t.Run("it should only bind the declared fields of nested objects", func(t *testing.T) {
+		type profile struct {
+			Meta    map[string]any `json:"meta"`
+			Address struct {
+				City string `json:"city"`
+				Zip  string `json:"zip"`
+			} `json:"address"`
+		}
+		bound, _, err := Bind[profile](DataObject{
+			"meta":    DataObject{"admin": true},
+			"address": DataObject{"city": "Bogor", "zip": "16111"},
+		}, SchemaRules{
+			"meta":    Object{},
+			"address": Object{Item: SchemaObject{"city": String{}}},
+		}, Options{})
+		if err != nil {
+			t.Fatalf("Actual = %v, Expected = %v", err, nil)
+		}
+		if len(bound.Meta) != 0 || bound.Address.City != "Bogor" || bound.Address.Zip != "" {
+			t.Errorf("Actual = %+v, Expected only address.city", bound)
+		}
+	})
+	t.Run("it should parse the text of time fields", func(t *testing.T) {
+		type event struct {
+			At   time.Time  `json:"at"`
+			Ends *time.Time `json:"ends"`
+		}
+		schema := SchemaRules{"at": String{Required: true}, "ends": String{}}
+		bound, _, err := Bind[event](DataObject{"at": "2024-05-01T10:00:00Z", "ends": "2024-05-01T12:00:00+07:00"}, schema, Options{})
+		if err != nil {
+			t.Fatalf("Actual = %v, Expected = %v", err, nil)
+		}
+		if !bound.At.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) || bound.Ends == nil || !bound.Ends.Equal(time.Date(2024, 5, 1, 5, 0, 0, 0, time.UTC)) {
+			t.Errorf("Actual = %+v", bound)
+		}
+		_, _, err = Bind[event](DataObject{"at": "tomorrow"}, schema, Options{})
+		var bindErr *BindError
+		var parseErr *time.ParseError
+		if !errors.As(err, &bindErr) || !errors.As(err, &parseErr) {
+			t.Errorf("Actual = %v, Expected a BindError wrapping a time.ParseError", err)
+		}
+	})
+	t.Run("it should only accept decoded JSON numbers for integer rules with Coerce", func(t *testing.T) {
+		var data DataObject
+		if err := json.Unmarshal([]byte(`{"name":"tono","age":30}`), &data); err != nil {
+			t.Fatal(err)
+		}
+		schema := SchemaRules{"name": String{Required: true}, "age": Numeric[int]{Required: true}}
+		_, bags, err := Bind[bindTestRequest](data, schema, Options{})
+		expected := []string{"age must be type of int"}
+		if err == nil || !reflect.DeepEqual(bags.Errors["age"], expected) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["age"], expected)
+		}
+		request, _, err := Bind[bindTestRequest](data, schema, Options{Coerce: true})
+		if err != nil || request.Age != 30 {
+			t.Errorf("Actual = %+v, %v, Expected age = 30", request, err)
+		}
+	})
+	t.Run("it should fail to bind numbers that lose precision", func(t *testing.T) {
+		_, _, err := Bind[bindTestRequest](DataObject{"name": "tono", "age": 1.5}, rules, Options{})
+		var bindErr *BindError
+		if !errors.As(err, &bindErr) {
+			t.Errorf("Actual = %v, Expected a BindError", err)
+		}
+	})
+}
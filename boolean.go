@@ -1,7 +1,6 @@
 package validet
 
 import (
-	"fmt"
 	"reflect"
 
 	"github.com/tidwall/gjson"
@@ -19,17 +18,18 @@ type Boolean struct {
 	RequiredIf     *RequiredIf
 	RequiredUnless *RequiredUnless
 	Custom         func(v bool, path PathKey, look Lookup) error
+	Default        any
 	Message        BooleanErrorMessage
 }
 
-func (s Boolean) isMyTypeOf(schema any) bool {
+func (s Boolean) IsMyTypeOf(schema any) bool {
 	return reflect.TypeOf(schema).Kind() == reflect.Struct && reflect.TypeOf(schema) == reflect.TypeOf(Boolean{})
 }
 
-func (s Boolean) process(params RuleParams) ([]string, error) {
+func (s Boolean) Process(params RuleContext) ([]FieldError, error) {
 	// errorBags := params.ErrorBags
-	schemaData := params.DataKey.(DataObject)
-	return params.Schema.validate(params.OriginalData, schemaData[params.Key], params)
+	value := params.Value()
+	return params.Schema.Validate(params.OriginalData, value, params)
 	// pathKey := params.PathKey + params.Key
 	// if err != nil {
 	// 	errorBags.append(pathKey, bags)
@@ -40,11 +40,18 @@ func (s Boolean) process(params RuleParams) ([]string, error) {
 	// return nil
 }
 
-func (s Boolean) validate(source []byte, value any, params RuleParams) ([]string, error) {
-	var bags []string
+func (s Boolean) Validate(source []byte, value any, params RuleContext) ([]FieldError, error) {
+	var bags []FieldError
 	key := params.Key
 	option := params.Option
 
+	if option.Coerce {
+		value = coerceBoolean(coerceEmpty(value))
+		setCoerced(params, value)
+	}
+
+	value = applyDefault(s.Default, value, params)
+
 	err := s.assertRequired(key, value, &bags)
 
 	if err != nil {
@@ -85,14 +92,16 @@ func (s Boolean) validate(source []byte, value any, params RuleParams) ([]string
 	return bags, nil
 }
 
-func (s Boolean) assertType(key string, value any, bags *[]string) (bool, error) {
+func (s Boolean) assertType(key string, value any, bags *[]FieldError) (bool, error) {
 	var booleanValue bool
 	if isBooelanValue(value) {
 		booleanValue = value.(bool)
 	} else {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be type of boolean", key),
+			key,
+			FieldError{Rule: RuleType, Params: map[string]any{"type": "boolean"}, Value: value},
+			"type.boolean",
 			"",
 		)
 		return false, BooleanValidationError
@@ -100,12 +109,14 @@ func (s Boolean) assertType(key string, value any, bags *[]string) (bool, error)
 	return booleanValue, nil
 }
 
-func (s Boolean) assertRequired(key string, value any, bags *[]string) error {
+func (s Boolean) assertRequired(key string, value any, bags *[]FieldError) error {
 	if s.Required {
 		if value == nil {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return BooleanValidationError
@@ -114,13 +125,14 @@ func (s Boolean) assertRequired(key string, value any, bags *[]string) error {
 	return nil
 }
 
-func (s Boolean) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]string) error {
+func (s Boolean) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]FieldError) error {
 	if s.RequiredIf != nil && value == nil {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredIf.FieldPath)
-		if comparedValue.String() == s.RequiredIf.Value {
+		if s.RequiredIf.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+				"required_if",
 				s.Message.RequiredIf,
 			)
 			return BooleanValidationError
@@ -129,13 +141,14 @@ func (s Boolean) assertRequiredIf(jsonSource []byte, key string, value any, bags
 	return nil
 }
 
-func (s Boolean) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]string) error {
+func (s Boolean) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]FieldError) error {
 	if s.RequiredUnless != nil && value == nil {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredUnless.FieldPath)
-		if comparedValue.String() != s.RequiredUnless.Value {
+		if !s.RequiredUnless.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+				"required_unless",
 				s.Message.RequiredUnless,
 			)
 			return StringValidationError
@@ -144,14 +157,17 @@ func (s Boolean) assertRequiredUnless(jsonSource []byte, key string, value any,
 	return nil
 }
 
-func (s Boolean) assertCustomValidation(fc func(v bool, path PathKey, look Lookup) error, jsonSource []byte, value any, path PathKey, bags *[]string) error {
+func (s Boolean) assertCustomValidation(fc func(v bool, path PathKey, look Lookup) error, jsonSource []byte, value any, path PathKey, bags *[]FieldError) error {
 	err := fc(value.(bool), path, func(k string) gjson.Result {
 		return gjson.GetBytes(jsonSource, k)
 	})
 	if err != nil {
+		fe, messageKey := customFieldError(err, value)
 		appendErrorBags(
 			bags,
-			err.Error(),
+			path.Current,
+			fe,
+			messageKey,
 			s.Message.Custom,
 		)
 		return BooleanValidationError
@@ -0,0 +1,756 @@
+package validet
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RuleBuilder is implemented by the fluent rule builders returned by Str,
+// Num, Integer, Float, Bool, List, Obj, Each and Use. The builders are values:
+// every method returns a modified copy, so a partially configured builder can
+// be shared as a base for several fields.
+//
+//	rules, err := validet.BuildSchema(
+//		validet.Key("name", validet.Str().Required().Min(3).Max(50)),
+//		validet.Key("email", validet.Str().Required().Email()),
+//		validet.Key("address", validet.Obj(
+//			validet.Key("city", validet.Str().Required()),
+//		)),
+//		validet.Key("items", validet.Each(
+//			validet.Key("qty", validet.Integer().Min(1)),
+//		).Min(1)),
+//	)
+//
+// Build returns the existing rule structs, so built rules can be mixed with
+// struct literals in any schema. Misconfigurations, such as a Min greater
+// than the Max or an invalid regex, are reported by Build with the path of
+// the field.
+type RuleBuilder interface {
+	Build() (Rule, error)
+	build(path []string) (Rule, error)
+}
+
+// FieldBuilder is a named RuleBuilder, see Key.
+type FieldBuilder struct {
+	key  string
+	rule RuleBuilder
+}
+
+// Key names the rule of a field for BuildSchema, Obj and Each.
+func Key(key string, rule RuleBuilder) FieldBuilder {
+	return FieldBuilder{key: key, rule: rule}
+}
+
+// BuildSchema builds the fields into an OrderedRules schema.
+func BuildSchema(fields ...FieldBuilder) (OrderedRules, error) {
+	return buildFields(nil, fields)
+}
+
+// MustBuildSchema is like BuildSchema but panics when a field is
+// misconfigured.
+func MustBuildSchema(fields ...FieldBuilder) OrderedRules {
+	rules, err := BuildSchema(fields...)
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
+
+// Must builds the rule and panics when it is misconfigured, for use in
+// SchemaRules literals:
+//
+//	validet.SchemaRules{"name": validet.Must(validet.Str().Required().Max(50))}
+func Must(rule RuleBuilder) Rule {
+	built, err := rule.Build()
+	if err != nil {
+		panic(err)
+	}
+	return built
+}
+
+func buildFields(path []string, fields []FieldBuilder) (OrderedRules, error) {
+	rules := make(OrderedRules, 0, len(fields))
+	declared := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		fieldPath := append(path[:len(path):len(path)], field.key)
+		switch {
+		case field.key == "":
+			return nil, builderError(path, errors.New("empty field key"))
+		case declared[field.key]:
+			return nil, builderError(fieldPath, errors.New("duplicate field"))
+		case field.rule == nil:
+			return nil, builderError(fieldPath, errors.New("missing rule"))
+		}
+		declared[field.key] = true
+		rule, err := field.rule.build(fieldPath)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, Field{Key: field.key, Rule: rule})
+	}
+	return rules, nil
+}
+
+func builderError(path []string, err error) error {
+	if len(path) == 0 {
+		return fmt.Errorf("validet: %w", err)
+	}
+	return fmt.Errorf("validet: %s: %w", strings.Join(path, "."), err)
+}
+
+func defaultFunc[T any](fn func(look Lookup) T) any {
+	if fn == nil {
+		return nil
+	}
+	return DefaultFunc(func(look Lookup) any { return fn(look) })
+}
+
+func checkPresence(requiredIf *RequiredIf, requiredUnless *RequiredUnless) error {
+	if requiredIf != nil {
+		if requiredIf.FieldPath == "" {
+			return errors.New("required_if needs a field path")
+		}
+		if !comparableCondition(requiredIf.Value) {
+			return fmt.Errorf("required_if value %v is a %T, want a string, number, boolean or nil", requiredIf.Value, requiredIf.Value)
+		}
+	}
+	if requiredUnless != nil {
+		if requiredUnless.FieldPath == "" {
+			return errors.New("required_unless needs a field path")
+		}
+		if !comparableCondition(requiredUnless.Value) {
+			return fmt.Errorf("required_unless value %v is a %T, want a string, number, boolean or nil", requiredUnless.Value, requiredUnless.Value)
+		}
+	}
+	return nil
+}
+
+// comparableCondition reports whether conditionMatches can compare the
+// value.
+func comparableCondition(value any) bool {
+	switch value.(type) {
+	case nil, string, bool:
+		return true
+	}
+	_, ok := numericAsFloat(value)
+	return ok
+}
+
+// checkBounds reports negative bounds, which the rules ignore, and a lower
+// bound above the upper one.
+func checkBounds(minName string, min int, maxName string, max int) error {
+	if min < 0 {
+		return fmt.Errorf("%s %d is negative", minName, min)
+	}
+	if max < 0 {
+		return fmt.Errorf("%s %d is negative", maxName, max)
+	}
+	if min > 0 && max > 0 && min > max {
+		return fmt.Errorf("%s %d is greater than %s %d", minName, min, maxName, max)
+	}
+	return nil
+}
+
+func checkInNotIn[T comparable](in []T, notIn []T) error {
+	excluded := make(map[T]bool, len(notIn))
+	for _, value := range notIn {
+		excluded[value] = true
+	}
+	for _, value := range in {
+		if excluded[value] {
+			return fmt.Errorf("%v is both in and not_in", value)
+		}
+	}
+	return nil
+}
+
+func checkPatterns(patterns ...string) error {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// StringBuilder builds a String rule, see Str.
+type StringBuilder struct {
+	rule String
+	err  error
+}
+
+// Str starts a String rule.
+func Str() StringBuilder {
+	return StringBuilder{}
+}
+
+func (b StringBuilder) Required() StringBuilder {
+	b.rule.Required = true
+	return b
+}
+
+func (b StringBuilder) RequiredIf(fieldPath string, value any) StringBuilder {
+	b.rule.RequiredIf = &RequiredIf{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b StringBuilder) RequiredUnless(fieldPath string, value any) StringBuilder {
+	b.rule.RequiredUnless = &RequiredUnless{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b StringBuilder) Min(min int) StringBuilder {
+	b.rule.Min = min
+	return b
+}
+
+func (b StringBuilder) Max(max int) StringBuilder {
+	b.rule.Max = max
+	return b
+}
+
+func (b StringBuilder) Regex(pattern string) StringBuilder {
+	b.rule.Regex = pattern
+	return b
+}
+
+func (b StringBuilder) NotRegex(pattern string) StringBuilder {
+	b.rule.NotRegex = pattern
+	return b
+}
+
+func (b StringBuilder) In(values ...string) StringBuilder {
+	b.rule.In = append([]string(nil), values...)
+	return b
+}
+
+func (b StringBuilder) NotIn(values ...string) StringBuilder {
+	b.rule.NotIn = append([]string(nil), values...)
+	return b
+}
+
+func (b StringBuilder) Email() StringBuilder {
+	b.rule.Email = true
+	return b
+}
+
+func (b StringBuilder) Alpha() StringBuilder {
+	b.rule.Alpha = true
+	return b
+}
+
+func (b StringBuilder) AlphaNumeric() StringBuilder {
+	b.rule.AlphaNumeric = true
+	return b
+}
+
+// Url requires an http or https url, or only the given schemes.
+func (b StringBuilder) Url(schemes ...string) StringBuilder {
+	url := &Url{}
+	for _, scheme := range schemes {
+		switch scheme {
+		case urlHttp:
+			url.Http = true
+		case urlHttps:
+			url.Https = true
+		default:
+			if b.err == nil {
+				b.err = fmt.Errorf("unknown url scheme %q", scheme)
+			}
+		}
+	}
+	b.rule.Url = url
+	return b
+}
+
+func (b StringBuilder) Custom(fn func(v string, path PathKey, look Lookup) error) StringBuilder {
+	b.rule.Custom = fn
+	return b
+}
+
+// Transform adds transformers that clean the value before it is validated.
+func (b StringBuilder) Transform(fns ...Transformer) StringBuilder {
+	b.rule.Transform = append(b.rule.Transform[:len(b.rule.Transform):len(b.rule.Transform)], fns...)
+	return b
+}
+
+// Default is used when the value is absent.
+func (b StringBuilder) Default(value string) StringBuilder {
+	b.rule.Default = value
+	return b
+}
+
+// DefaultFunc computes the value used when the value is absent.
+func (b StringBuilder) DefaultFunc(fn func(look Lookup) string) StringBuilder {
+	b.rule.Default = defaultFunc(fn)
+	return b
+}
+
+func (b StringBuilder) Message(message StringErrorMessage) StringBuilder {
+	b.rule.Message = message
+	return b
+}
+
+func (b StringBuilder) Build() (Rule, error) {
+	return b.build(nil)
+}
+
+func (b StringBuilder) build(path []string) (Rule, error) {
+	s := b.rule
+	err := b.err
+	if err == nil {
+		err = checkPresence(s.RequiredIf, s.RequiredUnless)
+	}
+	if err == nil {
+		err = checkBounds("min", s.Min, "max", s.Max)
+	}
+	if err == nil {
+		err = checkPatterns(s.Regex, s.NotRegex)
+	}
+	if err == nil {
+		err = checkInNotIn(s.In, s.NotIn)
+	}
+	for _, fn := range s.Transform {
+		if err == nil && fn == nil {
+			err = errors.New("nil transformer")
+		}
+	}
+	if err == nil && s.Email && (s.Alpha || s.AlphaNumeric || s.Url != nil) {
+		err = errors.New("email can't be combined with alpha, alpha_numeric or url")
+	}
+	if err == nil && s.Url != nil && (s.Alpha || s.AlphaNumeric) {
+		err = errors.New("url can't be combined with alpha or alpha_numeric")
+	}
+	if err != nil {
+		return nil, builderError(path, err)
+	}
+	return s, nil
+}
+
+// NumericBuilder builds a Numeric rule, see Num.
+type NumericBuilder[NT NumericValue] struct {
+	rule Numeric[NT]
+}
+
+// Num starts a Numeric rule.
+func Num[NT NumericValue]() NumericBuilder[NT] {
+	return NumericBuilder[NT]{}
+}
+
+// Integer starts a Numeric[int] rule.
+func Integer() NumericBuilder[int] {
+	return Num[int]()
+}
+
+// Float starts a Numeric[float64] rule, the type of numbers decoded from
+// JSON.
+func Float() NumericBuilder[float64] {
+	return Num[float64]()
+}
+
+func (b NumericBuilder[NT]) Required() NumericBuilder[NT] {
+	b.rule.Required = true
+	return b
+}
+
+func (b NumericBuilder[NT]) RequiredIf(fieldPath string, value any) NumericBuilder[NT] {
+	b.rule.RequiredIf = &RequiredIf{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b NumericBuilder[NT]) RequiredUnless(fieldPath string, value any) NumericBuilder[NT] {
+	b.rule.RequiredUnless = &RequiredUnless{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b NumericBuilder[NT]) Min(min int) NumericBuilder[NT] {
+	b.rule.Min = min
+	return b
+}
+
+func (b NumericBuilder[NT]) Max(max int) NumericBuilder[NT] {
+	b.rule.Max = max
+	return b
+}
+
+func (b NumericBuilder[NT]) MinDigits(min int) NumericBuilder[NT] {
+	b.rule.MinDigits = min
+	return b
+}
+
+func (b NumericBuilder[NT]) MaxDigits(max int) NumericBuilder[NT] {
+	b.rule.MaxDigits = max
+	return b
+}
+
+func (b NumericBuilder[NT]) Regex(pattern string) NumericBuilder[NT] {
+	b.rule.Regex = pattern
+	return b
+}
+
+func (b NumericBuilder[NT]) NotRegex(pattern string) NumericBuilder[NT] {
+	b.rule.NotRegex = pattern
+	return b
+}
+
+func (b NumericBuilder[NT]) In(values ...NT) NumericBuilder[NT] {
+	b.rule.In = append([]NT(nil), values...)
+	return b
+}
+
+func (b NumericBuilder[NT]) NotIn(values ...NT) NumericBuilder[NT] {
+	b.rule.NotIn = append([]NT(nil), values...)
+	return b
+}
+
+func (b NumericBuilder[NT]) Custom(fn func(v NT, path PathKey, look Lookup) error) NumericBuilder[NT] {
+	b.rule.Custom = fn
+	return b
+}
+
+// Default is used when the value is absent.
+func (b NumericBuilder[NT]) Default(value NT) NumericBuilder[NT] {
+	b.rule.Default = value
+	return b
+}
+
+// DefaultFunc computes the value used when the value is absent.
+func (b NumericBuilder[NT]) DefaultFunc(fn func(look Lookup) NT) NumericBuilder[NT] {
+	b.rule.Default = defaultFunc(fn)
+	return b
+}
+
+func (b NumericBuilder[NT]) Message(message NumericErrorMessage) NumericBuilder[NT] {
+	b.rule.Message = message
+	return b
+}
+
+func (b NumericBuilder[NT]) Build() (Rule, error) {
+	return b.build(nil)
+}
+
+func (b NumericBuilder[NT]) build(path []string) (Rule, error) {
+	s := b.rule
+	err := checkPresence(s.RequiredIf, s.RequiredUnless)
+	if err == nil {
+		err = checkBounds("min", s.Min, "max", s.Max)
+	}
+	if err == nil {
+		err = checkBounds("min_digits", s.MinDigits, "max_digits", s.MaxDigits)
+	}
+	if err == nil {
+		err = checkPatterns(s.Regex, s.NotRegex)
+	}
+	if err == nil {
+		err = checkInNotIn(s.In, s.NotIn)
+	}
+	if err != nil {
+		return nil, builderError(path, err)
+	}
+	return s, nil
+}
+
+// BooleanBuilder builds a Boolean rule, see Bool.
+type BooleanBuilder struct {
+	rule Boolean
+}
+
+// Bool starts a Boolean rule.
+func Bool() BooleanBuilder {
+	return BooleanBuilder{}
+}
+
+func (b BooleanBuilder) Required() BooleanBuilder {
+	b.rule.Required = true
+	return b
+}
+
+func (b BooleanBuilder) RequiredIf(fieldPath string, value any) BooleanBuilder {
+	b.rule.RequiredIf = &RequiredIf{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b BooleanBuilder) RequiredUnless(fieldPath string, value any) BooleanBuilder {
+	b.rule.RequiredUnless = &RequiredUnless{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b BooleanBuilder) Custom(fn func(v bool, path PathKey, look Lookup) error) BooleanBuilder {
+	b.rule.Custom = fn
+	return b
+}
+
+// Default is used when the value is absent.
+func (b BooleanBuilder) Default(value bool) BooleanBuilder {
+	b.rule.Default = value
+	return b
+}
+
+// DefaultFunc computes the value used when the value is absent.
+func (b BooleanBuilder) DefaultFunc(fn func(look Lookup) bool) BooleanBuilder {
+	b.rule.Default = defaultFunc(fn)
+	return b
+}
+
+func (b BooleanBuilder) Message(message BooleanErrorMessage) BooleanBuilder {
+	b.rule.Message = message
+	return b
+}
+
+func (b BooleanBuilder) Build() (Rule, error) {
+	return b.build(nil)
+}
+
+func (b BooleanBuilder) build(path []string) (Rule, error) {
+	if err := checkPresence(b.rule.RequiredIf, b.rule.RequiredUnless); err != nil {
+		return nil, builderError(path, err)
+	}
+	return b.rule, nil
+}
+
+// SliceBuilder builds a Slice rule, see List.
+type SliceBuilder[T SliceValueType] struct {
+	rule Slice[T]
+}
+
+// List starts a Slice rule.
+func List[T SliceValueType]() SliceBuilder[T] {
+	return SliceBuilder[T]{}
+}
+
+func (b SliceBuilder[T]) Required() SliceBuilder[T] {
+	b.rule.Required = true
+	return b
+}
+
+func (b SliceBuilder[T]) RequiredIf(fieldPath string, value any) SliceBuilder[T] {
+	b.rule.RequiredIf = &RequiredIf{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b SliceBuilder[T]) RequiredUnless(fieldPath string, value any) SliceBuilder[T] {
+	b.rule.RequiredUnless = &RequiredUnless{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b SliceBuilder[T]) Min(min int) SliceBuilder[T] {
+	b.rule.Min = min
+	return b
+}
+
+func (b SliceBuilder[T]) Max(max int) SliceBuilder[T] {
+	b.rule.Max = max
+	return b
+}
+
+func (b SliceBuilder[T]) Custom(fn func(v []T, path PathKey, look Lookup) error) SliceBuilder[T] {
+	b.rule.Custom = fn
+	return b
+}
+
+// Default is used when the value is absent.
+func (b SliceBuilder[T]) Default(value []T) SliceBuilder[T] {
+	b.rule.Default = value
+	return b
+}
+
+// DefaultFunc computes the value used when the value is absent.
+func (b SliceBuilder[T]) DefaultFunc(fn func(look Lookup) []T) SliceBuilder[T] {
+	b.rule.Default = defaultFunc(fn)
+	return b
+}
+
+func (b SliceBuilder[T]) Message(message SliceErrorMessage) SliceBuilder[T] {
+	b.rule.Message = message
+	return b
+}
+
+func (b SliceBuilder[T]) Build() (Rule, error) {
+	return b.build(nil)
+}
+
+func (b SliceBuilder[T]) build(path []string) (Rule, error) {
+	err := checkPresence(b.rule.RequiredIf, b.rule.RequiredUnless)
+	if err == nil {
+		err = checkBounds("min", b.rule.Min, "max", b.rule.Max)
+	}
+	if err != nil {
+		return nil, builderError(path, err)
+	}
+	return b.rule, nil
+}
+
+// ObjectBuilder builds an Object rule, see Obj.
+type ObjectBuilder struct {
+	rule   Object
+	fields []FieldBuilder
+}
+
+// Obj starts an Object rule with the given fields, which are validated in
+// declaration order.
+func Obj(fields ...FieldBuilder) ObjectBuilder {
+	return ObjectBuilder{fields: append([]FieldBuilder(nil), fields...)}
+}
+
+func (b ObjectBuilder) Required() ObjectBuilder {
+	b.rule.Required = true
+	return b
+}
+
+func (b ObjectBuilder) RequiredIf(fieldPath string, value any) ObjectBuilder {
+	b.rule.RequiredIf = &RequiredIf{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b ObjectBuilder) RequiredUnless(fieldPath string, value any) ObjectBuilder {
+	b.rule.RequiredUnless = &RequiredUnless{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b ObjectBuilder) Custom(fn func(v DataObject, path PathKey, look Lookup) error) ObjectBuilder {
+	b.rule.Custom = fn
+	return b
+}
+
+// Default is used when the value is absent.
+func (b ObjectBuilder) Default(value DataObject) ObjectBuilder {
+	b.rule.Default = value
+	return b
+}
+
+// DefaultFunc computes the value used when the value is absent.
+func (b ObjectBuilder) DefaultFunc(fn func(look Lookup) DataObject) ObjectBuilder {
+	b.rule.Default = defaultFunc(fn)
+	return b
+}
+
+func (b ObjectBuilder) Message(message ObjectErrorMessage) ObjectBuilder {
+	b.rule.Message = message
+	return b
+}
+
+func (b ObjectBuilder) Build() (Rule, error) {
+	return b.build(nil)
+}
+
+func (b ObjectBuilder) build(path []string) (Rule, error) {
+	if err := checkPresence(b.rule.RequiredIf, b.rule.RequiredUnless); err != nil {
+		return nil, builderError(path, err)
+	}
+	fields, err := buildFields(path, b.fields)
+	if err != nil {
+		return nil, err
+	}
+	s := b.rule
+	s.Fields = fields
+	return s, nil
+}
+
+// SliceObjectBuilder builds a SliceObject rule, see Each.
+type SliceObjectBuilder struct {
+	rule   SliceObject
+	fields []FieldBuilder
+}
+
+// Each starts a SliceObject rule whose items have the given fields.
+func Each(fields ...FieldBuilder) SliceObjectBuilder {
+	return SliceObjectBuilder{fields: append([]FieldBuilder(nil), fields...)}
+}
+
+func (b SliceObjectBuilder) Required() SliceObjectBuilder {
+	b.rule.Required = true
+	return b
+}
+
+func (b SliceObjectBuilder) RequiredIf(fieldPath string, value any) SliceObjectBuilder {
+	b.rule.RequiredIf = &RequiredIf{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b SliceObjectBuilder) RequiredUnless(fieldPath string, value any) SliceObjectBuilder {
+	b.rule.RequiredUnless = &RequiredUnless{FieldPath: fieldPath, Value: value}
+	return b
+}
+
+func (b SliceObjectBuilder) Min(min int) SliceObjectBuilder {
+	b.rule.Min = min
+	return b
+}
+
+func (b SliceObjectBuilder) Max(max int) SliceObjectBuilder {
+	b.rule.Max = max
+	return b
+}
+
+func (b SliceObjectBuilder) Custom(fn func(v []DataObject, path PathKey, look Lookup) error) SliceObjectBuilder {
+	b.rule.Custom = fn
+	return b
+}
+
+// Default is used when the value is absent.
+func (b SliceObjectBuilder) Default(value []DataObject) SliceObjectBuilder {
+	b.rule.Default = value
+	return b
+}
+
+// DefaultFunc computes the value used when the value is absent.
+func (b SliceObjectBuilder) DefaultFunc(fn func(look Lookup) []DataObject) SliceObjectBuilder {
+	b.rule.Default = defaultFunc(fn)
+	return b
+}
+
+func (b SliceObjectBuilder) Message(message SliceObjectErrorMessage) SliceObjectBuilder {
+	b.rule.Message = message
+	return b
+}
+
+func (b SliceObjectBuilder) Build() (Rule, error) {
+	return b.build(nil)
+}
+
+func (b SliceObjectBuilder) build(path []string) (Rule, error) {
+	err := checkPresence(b.rule.RequiredIf, b.rule.RequiredUnless)
+	if err == nil {
+		err = checkBounds("min", b.rule.Min, "max", b.rule.Max)
+	}
+	if err != nil {
+		return nil, builderError(path, err)
+	}
+	fields, err := buildFields(path, b.fields)
+	if err != nil {
+		return nil, err
+	}
+	s := b.rule
+	s.Fields = fields
+	return s, nil
+}
+
+// Use wraps a rule declared as a struct literal, or any other Rule, so it
+// can be placed among built fields. Nested rules and regexes are checked
+// like Compile does, but the rule is used as it is.
+func Use(rule Rule) RuleBuilder {
+	return literalBuilder{rule: rule}
+}
+
+type literalBuilder struct {
+	rule Rule
+}
+
+func (b literalBuilder) Build() (Rule, error) {
+	return b.build(nil)
+}
+
+func (b literalBuilder) build(path []string) (Rule, error) {
+	if b.rule == nil {
+		return nil, builderError(path, errors.New("missing rule"))
+	}
+	if _, err := compileRule(path, b.rule); err != nil {
+		return nil, err
+	}
+	return b.rule, nil
+}
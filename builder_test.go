@@ -0,0 +1,136 @@
+package validet
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Builder(t *testing.T) {
+	t.Run("it should build the rule structs", func(t *testing.T) {
+		rules, err := BuildSchema(
+			Key("name", Str().Required().Min(3).Max(50)),
+			Key("email", Str().Required().Email()),
+			Key("site", Str().Url("https")),
+			Key("company", Str().RequiredIf("type", "company")),
+			Key("age", Integer().Min(17).NotIn(20, 21)),
+			Key("active", Bool()),
+			Key("tags", List[string]().Max(3)),
+			Key("address", Obj(
+				Key("city", Str().Required().Alpha()),
+			).Required()),
+			Key("items", Each(
+				Key("title", Str().Required()),
+				Key("qty", Float().Min(1)),
+			).Min(1)),
+			Key("note", Use(String{Max: 200})),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := OrderedRules{
+			{"name", String{Required: true, Min: 3, Max: 50}},
+			{"email", String{Required: true, Email: true}},
+			{"site", String{Url: &Url{Https: true}}},
+			{"company", String{RequiredIf: &RequiredIf{FieldPath: "type", Value: "company"}}},
+			{"age", Numeric[int]{Min: 17, NotIn: []int{20, 21}}},
+			{"active", Boolean{}},
+			{"tags", Slice[string]{Max: 3}},
+			{"address", Object{Required: true, Fields: OrderedRules{
+				{"city", String{Required: true, Alpha: true}},
+			}}},
+			{"items", SliceObject{Min: 1, Fields: OrderedRules{
+				{"title", String{Required: true}},
+				{"qty", Numeric[float64]{Min: 1}},
+			}}},
+			{"note", String{Max: 200}},
+		}
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rules, expected)
+		}
+	})
+
+	t.Run("it should not change a shared base builder", func(t *testing.T) {
+		base := Str().Required().In("a", "b")
+		short := Must(base.Max(3))
+		plain := Must(base)
+		if plain.(String).Max != 0 || short.(String).Max != 3 {
+			t.Errorf("Actual = %#v and %#v", plain, short)
+		}
+	})
+
+	t.Run("it should validate alongside struct literals", func(t *testing.T) {
+		rules := SchemaRules{
+			"name":  Must(Str().Required().Min(3)),
+			"email": String{Required: true, Email: true},
+		}
+		bags, _ := ValidateJSON([]byte(`{"name": "Al", "email": "al@mail.com"}`), rules, Options{})
+		if _, ok := bags.Errors["name"]; !ok || len(bags.Errors) != 1 {
+			t.Errorf("Actual = %v, Expected only a name error", bags.Errors)
+		}
+	})
+
+	t.Run("it should report misconfigured rules", func(t *testing.T) {
+		tests := []struct {
+			builder  RuleBuilder
+			expected string
+		}{
+			{Str().Min(5).Max(3), "validet: min 5 is greater than max 3"},
+			{Str().Min(-1), "validet: min -1 is negative"},
+			{Str().Regex("[a-"), `validet: invalid regex "[a-"`},
+			{Str().In("a", "b").NotIn("b"), "validet: b is both in and not_in"},
+			{Str().Email().Alpha(), "validet: email can't be combined with alpha, alpha_numeric or url"},
+			{Str().Url("ftp"), `validet: unknown url scheme "ftp"`},
+			{Str().RequiredIf("", "x"), "validet: required_if needs a field path"},
+			{Integer().MinDigits(4).MaxDigits(2), "validet: min_digits 4 is greater than max_digits 2"},
+			{List[int]().Min(3).Max(1), "validet: min 3 is greater than max 1"},
+			{Obj(Key("user", Obj(Key("name", Str().Min(9).Max(1))))), "validet: user.name: min 9 is greater than max 1"},
+			{Each(Key("title", Str()), Key("title", Str())), "validet: title: duplicate field"},
+			{Each(Key("title", nil)), "validet: title: missing rule"},
+			{Obj(Key("note", Use(String{Regex: "(a"}))), `validet: note: invalid regex "(a"`},
+		}
+		for _, tt := range tests {
+			_, err := tt.builder.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Actual = %v, Expected to contain = %s", err, tt.expected)
+			}
+		}
+	})
+
+	t.Run("it should panic on misconfigured rules with Must", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected a panic")
+			}
+		}()
+		MustBuildSchema(Key("name", Str().Min(5).Max(3)))
+	})
+	t.Run("it should compare conditions by the type of their value", func(t *testing.T) {
+		schema, _ := CompileOrdered(MustBuildSchema(
+			Key("guardian", Str().RequiredIf("age", 17)),
+			Key("company", Str().RequiredIf("employed", true)),
+			Key("reason", Str().RequiredUnless("score", 9.5)),
+			Key("note", Str().RequiredIf("comment", nil)),
+		))
+		bags, _ := schema.ValidateJSON([]byte(`{"age": 17, "employed": true, "score": 7}`), Options{})
+		expected := map[string][]string{
+			"guardian": {"guardian is required"},
+			"company":  {"company is required"},
+			"reason":   {"reason is required"},
+			"note":     {"note is required"},
+		}
+		if !reflect.DeepEqual(bags.Errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, expected)
+		}
+		bags, _ = schema.ValidateJSON([]byte(`{"age": 18, "employed": false, "score": 9.5, "comment": "ok"}`), Options{})
+		if len(bags.Errors) > 0 {
+			t.Errorf("Actual = %v, Expected no errors", bags.Errors)
+		}
+	})
+	t.Run("it should reject condition values that can't be compared", func(t *testing.T) {
+		_, err := BuildSchema(Key("city", Str().RequiredIf("address", []string{"a"})))
+		if err == nil || !strings.Contains(err.Error(), "want a string, number, boolean or nil") {
+			t.Errorf("Actual = %v, Expected a condition value error", err)
+		}
+	})
+}
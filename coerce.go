@@ -0,0 +1,115 @@
+package validet
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// coerceNumber converts the value to the numeric type t for Options.Coerce.
+// Numeric strings and numbers of any type are converted as long as no data
+// is lost; ok is false when it would be, e.g. 1.5 into an int or 5000000000
+// into a uint32. Values that aren't numbers, and any value when t is not
+// numeric, are returned as they are for the type assertion to report.
+func coerceNumber(value any, t reflect.Type) (coerced any, ok bool) {
+	if t.Kind() == reflect.String {
+		return value, true
+	}
+	if s, isString := value.(string); isString {
+		number, err := parseNumber(s)
+		switch {
+		case errors.Is(err, strconv.ErrRange):
+			return value, false
+		case err != nil:
+			return value, true
+		}
+		value = number
+	}
+	if _, isNumber := numericAsFloat(value); !isNumber {
+		return value, true
+	}
+	dst := reflect.New(t).Elem()
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := numericAsInt(value)
+		if !ok || dst.OverflowInt(i) {
+			return value, false
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, ok := numericAsUint(value)
+		if !ok || dst.OverflowUint(u) {
+			return value, false
+		}
+		dst.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, _ := numericAsFloat(value)
+		if dst.OverflowFloat(f) {
+			return value, false
+		}
+		dst.SetFloat(f)
+	}
+	return dst.Interface(), true
+}
+
+// parseNumber reads a decimal integer or float, keeping integers that don't
+// fit a float64 exactly as int64 or uint64.
+func parseNumber(s string) (any, error) {
+	s = strings.TrimSpace(s)
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return u, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, strconv.ErrSyntax
+	}
+	return f, nil
+}
+
+// coerceBoolean converts the strings accepted by strconv.ParseBool and the
+// "on" and "off" of HTML checkboxes.
+func coerceBoolean(value any) any {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	switch s = strings.TrimSpace(s); strings.ToLower(s) {
+	case "on":
+		return true
+	case "off":
+		return false
+	}
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	return value
+}
+
+// coerceEmpty treats the empty strings sent for blank form fields as absent
+// values.
+func coerceEmpty(value any) any {
+	if s, ok := value.(string); ok && strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return value
+}
+
+// setCoerced records the coerced value in the output.
+func setCoerced(params RuleContext, value any) {
+	if params.Output == nil {
+		return
+	}
+	if value == nil {
+		delete(params.Output, params.Key)
+		return
+	}
+	params.Output[params.Key] = value
+}
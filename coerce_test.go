@@ -0,0 +1,88 @@
+package validet
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func Test_Coerce(t *testing.T) {
+	schema := SchemaRules{
+		"page":    Numeric[int]{Required: true, Min: 1},
+		"price":   Numeric[float64]{},
+		"size":    Numeric[uint32]{},
+		"active":  Boolean{Required: true},
+		"remote":  Boolean{},
+		"ids":     Slice[int]{},
+		"note":    String{},
+		"comment": Numeric[int]{Default: 10},
+	}
+
+	t.Run("it should coerce form values", func(t *testing.T) {
+		form := url.Values{"page": {"2"}, "price": {" 9.5 "}, "size": {"4096"}, "active": {"on"}, "remote": {"false"}, "note": {"42"}, "comment": {""}}
+		data := DataObject{}
+		for key, values := range form {
+			data[key] = values[0]
+		}
+		data["ids"] = []any{"1", "2"}
+		output, bags, err := Bind[DataObject](data, schema, Options{Coerce: true})
+		if err != nil {
+			t.Fatalf("unexpected errors %v", bags.Errors)
+		}
+		expected := DataObject{
+			"page":    2,
+			"price":   9.5,
+			"size":    uint32(4096),
+			"active":  true,
+			"remote":  false,
+			"ids":     []any{1, 2},
+			"note":    "42",
+			"comment": 10,
+		}
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", output, expected)
+		}
+		if data["page"] != "2" {
+			t.Errorf("the data was modified: %#v", data)
+		}
+	})
+
+	t.Run("it should coerce integral JSON numbers", func(t *testing.T) {
+		bags, err := ValidateJSON([]byte(`{"page": 3, "active": true, "ids": [1, 2.0]}`), schema, Options{Coerce: true})
+		if err != nil {
+			t.Errorf("unexpected errors %v", bags.Errors)
+		}
+	})
+
+	t.Run("it should report conversions losing data", func(t *testing.T) {
+		bags, _ := ValidateJSON([]byte(`{"page": 1.5, "active": "maybe", "size": 5000000000, "price": "1e999", "ids": [1, -1.5]}`), schema, Options{Coerce: true})
+		expected := map[string][]string{
+			"page":   {"page can't be converted to int without losing data"},
+			"active": {"active must be type of boolean"},
+			"size":   {"size can't be converted to uint32 without losing data"},
+			"price":  {"price can't be converted to float64 without losing data"},
+			"ids":    {"ids can't be converted to int without losing data"},
+		}
+		if !reflect.DeepEqual(bags.Errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, expected)
+		}
+	})
+
+	t.Run("it should treat blank values as absent", func(t *testing.T) {
+		bags, _ := ValidateJSON([]byte(`{"page": " ", "active": ""}`), schema, Options{Coerce: true})
+		expected := map[string][]string{
+			"page":   {"page is required"},
+			"active": {"active is required"},
+		}
+		if !reflect.DeepEqual(bags.Errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, expected)
+		}
+	})
+
+	t.Run("it should keep the strict types without Coerce", func(t *testing.T) {
+		bags, _ := ValidateJSON([]byte(`{"page": "2", "active": true}`), schema, Options{})
+		if _, ok := bags.Errors["page"]; !ok {
+			t.Errorf("Actual = %v, Expected a page error", bags.Errors)
+		}
+	})
+}
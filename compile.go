@@ -0,0 +1,195 @@
+package validet
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CompiledSchema is a schema prepared once by Compile: nested Object and
+// SliceObject items are resolved to ordered fields and the Regex and
+// NotRegex patterns are compiled into the rules holding them, where schemas
+// validated without Compile compile them on every validation. A
+// CompiledSchema is immutable and safe for concurrent use by multiple
+// goroutines.
+type CompiledSchema struct {
+	fields OrderedRules
+}
+
+// compiler is implemented by the built-in rules that have something to
+// prepare. Rules that don't implement it are used as they are.
+type compiler interface {
+	compile(path []string) (Rule, error)
+}
+
+// Compile prepares the rules for repeated validation. Invalid regexes are
+// reported here instead of failing every validated value.
+func Compile(rules SchemaRules) (*CompiledSchema, error) {
+	return CompileOrdered(sortedFields(rules))
+}
+
+// CompileOrdered is Compile for an OrderedRules schema.
+func CompileOrdered(rules OrderedRules) (*CompiledSchema, error) {
+	fields, err := compileFields(nil, rules)
+	if err != nil {
+		return nil, err
+	}
+	return &CompiledSchema{fields: fields}, nil
+}
+
+// Validate validates the data against the compiled schema.
+func (c *CompiledSchema) Validate(data DataObject, options Options) (ErrorBag, error) {
+	return run(&Validation{
+		data:    data,
+		schema:  c.fields,
+		options: options,
+	})
+}
+
+// ValidateJSON validates a raw JSON object against the compiled schema, like
+// the package level ValidateJSON.
+func (c *CompiledSchema) ValidateJSON(source []byte, options Options) (ErrorBag, error) {
+	if !validJSONObject(source) {
+		return ErrorBag{}, ErrInvalidJSON
+	}
+	return run(&Validation{
+		source:  source,
+		schema:  c.fields,
+		options: options,
+	})
+}
+
+// Validated is Validate returning the validated values when the validation
+// passes, like the data returned by Bind.
+func (c *CompiledSchema) Validated(data DataObject, options Options) (DataObject, ErrorBag, error) {
+	return runValidated(&Validation{
+		data:    data,
+		schema:  c.fields,
+		options: options,
+	})
+}
+
+// ValidatedJSON is ValidateJSON returning the validated values when the
+// validation passes, like the data returned by Bind.
+func (c *CompiledSchema) ValidatedJSON(source []byte, options Options) (DataObject, ErrorBag, error) {
+	if !validJSONObject(source) {
+		return nil, ErrorBag{}, ErrInvalidJSON
+	}
+	return runValidated(&Validation{
+		source:  source,
+		schema:  c.fields,
+		options: options,
+	})
+}
+
+func compileFields(path []string, fields OrderedRules) (OrderedRules, error) {
+	compiled := make(OrderedRules, 0, len(fields))
+	for _, field := range fields {
+		rule, err := compileRule(append(path[:len(path):len(path)], field.Key), field.Rule)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, Field{Key: field.Key, Rule: rule})
+	}
+	return compiled, nil
+}
+
+func compileRule(path []string, rule Rule) (Rule, error) {
+	if rule == nil {
+		return nil, fmt.Errorf("validet: %s: missing rule", strings.Join(path, "."))
+	}
+	if c, ok := rule.(compiler); ok {
+		return c.compile(path)
+	}
+	return rule, nil
+}
+
+// compileItem resolves the fields of an Object or SliceObject, rejecting
+// Item values that are not rules instead of silently ignoring them.
+func compileItem(path []string, fields OrderedRules, item SchemaObject) (OrderedRules, error) {
+	for key, value := range item {
+		if _, ok := isRule(value); !ok {
+			return nil, fmt.Errorf("validet: %s.%s: %T is not a rule", strings.Join(path, "."), key, value)
+		}
+	}
+	return compileFields(path, itemFields(fields, item))
+}
+
+func compilePattern(path []string, pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	regx, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("validet: %s: invalid regex %q: %w", strings.Join(path, "."), pattern, err)
+	}
+	return regx, nil
+}
+
+func (s String) compile(path []string) (Rule, error) {
+	var err error
+	if s.regex, err = compilePattern(path, s.Regex); err != nil {
+		return nil, err
+	}
+	if s.notRegex, err = compilePattern(path, s.NotRegex); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s Numeric[NT]) compile(path []string) (Rule, error) {
+	var err error
+	if s.regex, err = compilePattern(path, s.Regex); err != nil {
+		return nil, err
+	}
+	if s.notRegex, err = compilePattern(path, s.NotRegex); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s Object) compile(path []string) (Rule, error) {
+	fields, err := compileItem(path, s.Fields, s.Item)
+	if err != nil {
+		return nil, err
+	}
+	s.Fields, s.Item = fields, nil
+	return s, nil
+}
+
+func (s SliceObject) compile(path []string) (Rule, error) {
+	fields, err := compileItem(path, s.Fields, s.Item)
+	if err != nil {
+		return nil, err
+	}
+	s.Fields, s.Item = fields, nil
+	return s, nil
+}
+
+func (s File) compile(path []string) (Rule, error) {
+	for _, entry := range s.Mimes {
+		if _, ok := fileType(entry); !ok {
+			return nil, fmt.Errorf("validet: %s: unknown file type %q", strings.Join(path, "."), entry)
+		}
+	}
+	if s.Image != nil {
+		if err := s.Image.compile(path); err != nil {
+			return nil, err
+		}
+	}
+	if s.Archive != nil {
+		if err := s.Archive.compile(path); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s Files) compile(path []string) (Rule, error) {
+	file, err := s.File.compile(append(path[:len(path):len(path)], "*"))
+	if err != nil {
+		return nil, err
+	}
+	s.File = file.(File)
+	return s, nil
+}
@@ -0,0 +1,182 @@
+package validet
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func compileTestSchema() SchemaRules {
+	return SchemaRules{
+		"name":  String{Required: true, Min: 3, Regex: `^[a-z ]+$`},
+		"email": String{Required: true, Email: true},
+		"site":  String{Url: &Url{Https: true}},
+		"age":   Numeric[int]{Min: 17, NotRegex: `^9`},
+		"tags":  Slice[string]{Max: 3},
+		"address": Object{
+			Required: true,
+			Item: DataObject{
+				"city": String{Required: true, Alpha: true},
+			},
+		},
+		"items": SliceObject{
+			Item: DataObject{
+				"title": String{Required: true, AlphaNumeric: true},
+			},
+		},
+	}
+}
+
+func compileTestData() DataObject {
+	return DataObject{
+		"name":    "tono",
+		"email":   "tono@mail.com",
+		"site":    "https://validet.dev",
+		"age":     20,
+		"tags":    []any{"a", "b"},
+		"address": DataObject{"city": "Jakarta"},
+		"items":   []any{DataObject{"title": "first"}, DataObject{"title": "second"}},
+	}
+}
+
+func Test_Compile(t *testing.T) {
+	compiled, err := Compile(compileTestSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := compiled.Validate(compileTestData(), Options{}); err != nil {
+		t.Errorf("Actual = %v, Expected = %v", err, nil)
+	}
+
+	data := compileTestData()
+	data["name"] = "To"
+	data["address"] = DataObject{"city": "J4karta"}
+	bags, err := compiled.Validate(data, Options{})
+	if err == nil {
+		t.Fatalf("Actual = %v, Expected = %v", err, StringValidationError)
+	}
+	expected, _ := validate(data, compileTestSchema(), Options{})
+	if fmt.Sprint(bags.Errors) != fmt.Sprint(expected.Errors) {
+		t.Errorf("Actual = %v, Expected = %v", bags.Errors, expected.Errors)
+	}
+	if fmt.Sprint(bags.Keys) != "[address.city name]" {
+		t.Errorf("Actual = %v, Expected = %v", bags.Keys, "[address.city name]")
+	}
+
+	if _, err := compiled.ValidateJSON([]byte(`{"name": "tono"}`), Options{}); err == nil {
+		t.Errorf("Actual = %v, Expected = %v", err, "a missing field error")
+	}
+	if _, err := compiled.ValidateJSON([]byte(`[]`), Options{}); err != ErrInvalidJSON {
+		t.Errorf("Actual = %v, Expected = %v", err, ErrInvalidJSON)
+	}
+}
+
+func Test_Compile_Regex(t *testing.T) {
+	compiled, err := CompileOrdered(OrderedRules{
+		{"name", String{Regex: `^[a-z]+$`, NotRegex: `^admin$`}},
+		{"age", Numeric[int]{NotRegex: `^9`}},
+		{"user", Object{Item: DataObject{"code": Numeric[int]{Regex: `^\d{3}$`}}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := compiled.fields[0].Rule.(String)
+	if name.regex == nil || name.notRegex == nil {
+		t.Errorf("Actual = %v %v, Expected = compiled regexes", name.regex, name.notRegex)
+	}
+	if age := compiled.fields[1].Rule.(Numeric[int]); age.regex != nil || age.notRegex == nil {
+		t.Errorf("Actual = %v %v, Expected = only the not_regex compiled", age.regex, age.notRegex)
+	}
+	code := compiled.fields[2].Rule.(Object).Fields[0].Rule.(Numeric[int])
+	if code.regex == nil || code.regex.String() != `^\d{3}$` {
+		t.Errorf("Actual = %v, Expected = %v", code.regex, `^\d{3}$`)
+	}
+
+	bags, err := compiled.Validate(DataObject{"name": "admin", "age": 90, "user": DataObject{"code": 12}}, Options{})
+	if err == nil || len(bags.Errors) != 3 {
+		t.Errorf("Actual = %v, Expected = %v", bags.Errors, "name, age and user.code errors")
+	}
+}
+
+func Test_Compile_InvalidRegex(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema SchemaRules
+		path   string
+	}{
+		{"string", SchemaRules{"name": String{Regex: `[a-z`}}, "name"},
+		{"not regex", SchemaRules{"name": String{NotRegex: `(`}}, "name"},
+		{"numeric", SchemaRules{"age": Numeric[int]{Regex: `*`}}, "age"},
+		{"object", SchemaRules{"user": Object{Item: DataObject{"name": String{Regex: `[`}}}}, "user.name"},
+		{"slice object", SchemaRules{"users": SliceObject{Item: DataObject{"age": Numeric[float64]{NotRegex: `)`}}}}, "users.age"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.schema)
+			if err == nil {
+				t.Fatalf("Actual = %v, Expected = %v", err, "a compile error")
+			}
+			if !strings.Contains(err.Error(), tt.path+": invalid regex") {
+				t.Errorf("Actual = %v, Expected = %v", err, tt.path+": invalid regex")
+			}
+		})
+	}
+
+	_, err := Compile(SchemaRules{"user": Object{Item: DataObject{"name": "string"}}})
+	if err == nil || !strings.Contains(err.Error(), "user.name: string is not a rule") {
+		t.Errorf("Actual = %v, Expected = %v", err, "user.name: string is not a rule")
+	}
+}
+
+func Test_Compile_Concurrent(t *testing.T) {
+	compiled, err := Compile(compileTestSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data := compileTestData()
+			if i%2 == 1 {
+				data["email"] = "invalid"
+			}
+			for j := 0; j < 50; j++ {
+				bags, err := compiled.Validate(data, Options{})
+				if (err != nil) != (i%2 == 1) {
+					t.Errorf("goroutine %d: Actual = %v, Expected = %v", i, bags.Errors, i%2 == 1)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// BenchmarkValidate compiles the regexes of the schema on every validation,
+// which BenchmarkCompiledValidate does once.
+func BenchmarkValidate(b *testing.B) {
+	schema := compileTestSchema()
+	data := compileTestData()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		validate(data, schema, Options{})
+	}
+}
+
+func BenchmarkCompiledValidate(b *testing.B) {
+	compiled, err := Compile(compileTestSchema())
+	if err != nil {
+		b.Fatal(err)
+	}
+	data := compileTestData()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		compiled.Validate(data, Options{})
+	}
+}
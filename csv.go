@@ -0,0 +1,230 @@
+package validet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingHeader is returned when a file read with a header row, i.e.
+// without CSVOptions.Columns, is empty.
+var ErrMissingHeader = errors.New("csv: missing header row")
+
+// ErrMissingColumn is returned, wrapped with the title, when a title of
+// CSVOptions.Header is not in the header row.
+var ErrMissingColumn = errors.New("csv: missing column")
+
+// CSVOptions configures ValidateCSV.
+type CSVOptions struct {
+	// Options are the validation options. Coerce is always enabled, the
+	// cells are all strings.
+	Options Options
+	// Header maps the column titles of the header row to the fields of the
+	// schema, e.g. "E-mail address" to "email". Columns missing from it are
+	// ignored, titles missing from the header row fail with
+	// ErrMissingColumn. When Header is nil, the titles are the field names.
+	Header map[string]string
+	// Columns lists the field of each column of files without a header row,
+	// an empty string skipping the column. The first row is then data.
+	Columns []string
+	// Comma is the field delimiter, ',' when 0.
+	Comma rune
+	// Key is the first element of the error keys, "rows" when empty.
+	Key string
+	// MaxRows stops the validation after that many data rows, MaxErrors once
+	// that many fields failed. Either way Truncated is set in the summary.
+	MaxRows   int
+	MaxErrors int
+	// Valid is called with the number and the validated data of every valid
+	// row, like the data returned by Bind, to import the rows while they are
+	// streamed. An error stops the validation and is returned as is.
+	Valid func(row int, data DataObject) error
+}
+
+// CSVSummary is the result of ValidateCSV. Total counts the validated data
+// rows, Valid and Invalid the ones that passed and failed, and Errors holds
+// the failed fields keyed by row number and field, e.g. "rows.17.email".
+type CSVSummary struct {
+	Total     int
+	Valid     int
+	Invalid   int
+	Truncated bool
+	Errors    ErrorBag
+}
+
+// ValidateCSV streams the CSV rows read from r and validates each of them
+// against the item schema, like the items of a SliceObject. A row is only
+// held in memory while it is validated, and MaxRows and MaxErrors bound the
+// work and the errors kept for huge files.
+//
+// Rows are numbered like the lines of a spreadsheet: with a header row, the
+// first data row is row 2. Cells are converted to the types of the rules as
+// with Options.Coerce, and empty cells are absent values. Rows shorter than
+// the header leave their last fields absent.
+//
+// The error is only set when the file can't be read, the schema doesn't
+// compile or Header and Columns don't match the schema or the file; invalid
+// rows are reported by the summary.
+func ValidateCSV(r io.Reader, schema SchemaRules, options CSVOptions) (CSVSummary, error) {
+	summary := CSVSummary{Errors: *NewErrorBags()}
+	fields, err := compileFields(nil, sortedFields(schema))
+	if err != nil {
+		return summary, err
+	}
+	key := options.Key
+	if key == "" {
+		key = "rows"
+	}
+	validation := options.Options
+	validation.Coerce = true
+
+	reader := csv.NewReader(r)
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	if err := csvFields(schema, options); err != nil {
+		return summary, err
+	}
+	columns := options.Columns
+	row := 0
+	if columns == nil {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return summary, fmt.Errorf("validet: %w", ErrMissingHeader)
+		}
+		if err != nil {
+			return summary, fmt.Errorf("validet: %w", err)
+		}
+		if columns, err = csvColumns(record, options.Header); err != nil {
+			return summary, err
+		}
+		row++
+	}
+
+	// stop ends the validation before the end of the file, which is only
+	// truncated if rows are left.
+	stop := func() (CSVSummary, error) {
+		more, err := csvMore(reader)
+		if err != nil {
+			return summary, fmt.Errorf("validet: %w", err)
+		}
+		summary.Truncated = more
+		return summary, nil
+	}
+	for {
+		if options.MaxRows > 0 && summary.Total >= options.MaxRows {
+			return stop()
+		}
+		record, err := reader.Read()
+		if err == io.EOF {
+			return summary, nil
+		}
+		if err != nil {
+			return summary, fmt.Errorf("validet: %w", err)
+		}
+		row++
+
+		data := csvRow(columns, record)
+		output := DataObject{}
+		bags := NewErrorBags()
+		source, _ := json.Marshal(data)
+		mapSchemas(source, []string{key, strconv.Itoa(row)}, "", data, fields, bags, validation, output)
+
+		summary.Total++
+		if len(bags.Keys) == 0 {
+			summary.Valid++
+			if options.Valid != nil {
+				if err := options.Valid(row, output); err != nil {
+					return summary, err
+				}
+			}
+			continue
+		}
+		summary.Invalid++
+		for _, k := range bags.Keys {
+			if options.MaxErrors > 0 && len(summary.Errors.Keys) >= options.MaxErrors {
+				summary.Truncated = true
+				return summary, nil
+			}
+			summary.Errors.append(k, bags.Fields[k])
+		}
+		if validation.AbortEarly {
+			return stop()
+		}
+	}
+}
+
+// csvFields checks that Header and Columns only name fields of the schema.
+func csvFields(schema SchemaRules, options CSVOptions) error {
+	titles := make([]string, 0, len(options.Header))
+	for title := range options.Header {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+	for _, title := range titles {
+		if _, ok := schema[options.Header[title]]; !ok {
+			return fmt.Errorf("validet: csv: column %q maps to the unknown field %q", title, options.Header[title])
+		}
+	}
+	for i, field := range options.Columns {
+		if _, ok := schema[field]; field != "" && !ok {
+			return fmt.Errorf("validet: csv: column %d maps to the unknown field %q", i+1, field)
+		}
+	}
+	return nil
+}
+
+// csvColumns maps the header titles to the fields of the schema, dropping
+// the byte order mark some spreadsheets write before the first title.
+func csvColumns(header []string, mapping map[string]string) ([]string, error) {
+	columns := make([]string, len(header))
+	found := map[string]bool{}
+	for i, title := range header {
+		title = strings.TrimSpace(strings.TrimPrefix(title, "\ufeff"))
+		if mapping == nil {
+			columns[i] = title
+		} else {
+			columns[i] = mapping[title]
+			found[title] = true
+		}
+	}
+	titles := make([]string, 0, len(mapping))
+	for title := range mapping {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+	for _, title := range titles {
+		if !found[title] {
+			return nil, fmt.Errorf("validet: %w %q", ErrMissingColumn, title)
+		}
+	}
+	return columns, nil
+}
+
+func csvRow(columns []string, record []string) DataObject {
+	data := DataObject{}
+	for i, field := range columns {
+		if field == "" || i >= len(record) || record[i] == "" {
+			continue
+		}
+		data[field] = record[i]
+	}
+	return data
+}
+
+// csvMore reports whether the reader has rows left, returning the error of
+// a row that can't be read.
+func csvMore(reader *csv.Reader) (bool, error) {
+	if _, err := reader.Read(); err != io.EOF {
+		return err == nil, err
+	}
+	return false, nil
+}
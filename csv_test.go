@@ -0,0 +1,133 @@
+package validet
+
+import (
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ValidateCSV(t *testing.T) {
+	schema := SchemaRules{
+		"name":   String{Required: true},
+		"email":  String{Required: true, Email: true},
+		"age":    Numeric[int]{Min: 18},
+		"active": Boolean{},
+	}
+	source := "\ufeffName,E-mail,Age,Active,Notes\n" +
+		"Ann,ann@example.com,30,true,\n" +
+		"Bob,bob-at-example,17,false,\n" +
+		",carl@example.com,,,\"multi\nline\"\n" +
+		"Dee,dee@example.com\n"
+	header := map[string]string{"Name": "name", "E-mail": "email", "Age": "age", "Active": "active"}
+
+	t.Run("it should validate every row and summarize them", func(t *testing.T) {
+		var imported []DataObject
+		summary, err := ValidateCSV(strings.NewReader(source), schema, CSVOptions{
+			Header: header,
+			Valid: func(row int, data DataObject) error {
+				imported = append(imported, data)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if summary.Total != 4 || summary.Valid != 2 || summary.Invalid != 2 || summary.Truncated {
+			t.Errorf("Actual = %+v", summary)
+		}
+		expected := map[string][]string{
+			"rows.3.email": {"email is not a valid email"},
+			"rows.3.age":   {"age must be minimum of 18"},
+			"rows.4.name":  {"name is required"},
+		}
+		if !reflect.DeepEqual(summary.Errors.Errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", summary.Errors.Errors, expected)
+		}
+		expectedData := []DataObject{
+			{"name": "Ann", "email": "ann@example.com", "age": 30, "active": true},
+			{"name": "Dee", "email": "dee@example.com"},
+		}
+		if !reflect.DeepEqual(imported, expectedData) {
+			t.Errorf("Actual = %v, Expected = %v", imported, expectedData)
+		}
+	})
+
+	t.Run("it should read files without a header row", func(t *testing.T) {
+		summary, err := ValidateCSV(strings.NewReader("x;ann@example.com;Ann\n"), schema, CSVOptions{
+			Columns: []string{"", "email", "name"},
+			Comma:   ';',
+		})
+		if err != nil || summary.Total != 1 || summary.Valid != 1 {
+			t.Errorf("Actual = %+v, %v", summary, err)
+		}
+	})
+
+	t.Run("it should stop at the row and error caps", func(t *testing.T) {
+		summary, _ := ValidateCSV(strings.NewReader(source), schema, CSVOptions{Header: header, MaxRows: 2})
+		if summary.Total != 2 || !summary.Truncated {
+			t.Errorf("Actual = %+v", summary)
+		}
+		summary, _ = ValidateCSV(strings.NewReader(source), schema, CSVOptions{Header: header, MaxErrors: 1})
+		if summary.Total != 2 || !summary.Truncated || !reflect.DeepEqual(summary.Errors.Keys, []string{"rows.3.age"}) {
+			t.Errorf("Actual = %+v", summary)
+		}
+		summary, _ = ValidateCSV(strings.NewReader(source), schema, CSVOptions{Header: header, MaxRows: 4})
+		if summary.Total != 4 || summary.Truncated {
+			t.Errorf("Actual = %+v", summary)
+		}
+		summary, _ = ValidateCSV(strings.NewReader(source), schema, CSVOptions{Header: header, Options: Options{AbortEarly: true}})
+		if summary.Total != 2 || summary.Invalid != 1 || !summary.Truncated {
+			t.Errorf("Actual = %+v", summary)
+		}
+		last := "Name,E-mail\nAnn,ann@example.com\nBob,bob-at-example\n"
+		summary, _ = ValidateCSV(strings.NewReader(last), schema, CSVOptions{Options: Options{AbortEarly: true}, Header: map[string]string{"Name": "name", "E-mail": "email"}})
+		if summary.Total != 2 || summary.Invalid != 1 || summary.Truncated {
+			t.Errorf("Actual = %+v", summary)
+		}
+	})
+
+	t.Run("it should reject columns not matching the schema or the file", func(t *testing.T) {
+		_, err := ValidateCSV(strings.NewReader(source), schema, CSVOptions{Header: map[string]string{"E-mail": "emial"}})
+		if err == nil || !strings.Contains(err.Error(), `unknown field "emial"`) {
+			t.Errorf("Actual = %v", err)
+		}
+		_, err = ValidateCSV(strings.NewReader("ann@example.com\n"), schema, CSVOptions{Columns: []string{"mail"}})
+		if err == nil || !strings.Contains(err.Error(), `unknown field "mail"`) {
+			t.Errorf("Actual = %v", err)
+		}
+		_, err = ValidateCSV(strings.NewReader(source), schema, CSVOptions{Header: map[string]string{"Email": "email"}})
+		if !errors.Is(err, ErrMissingColumn) {
+			t.Errorf("Actual = %v, Expected = %v", err, ErrMissingColumn)
+		}
+	})
+
+	t.Run("it should report unreadable files", func(t *testing.T) {
+		if _, err := ValidateCSV(strings.NewReader(""), schema, CSVOptions{}); !errors.Is(err, ErrMissingHeader) {
+			t.Errorf("Actual = %v, Expected = %v", err, ErrMissingHeader)
+		}
+		header := map[string]string{"Name": "name", "E-mail": "email"}
+		malformed := []struct {
+			source  string
+			options CSVOptions
+		}{
+			{"Name,E-mail\nAnn,ann@example.com\n\"Carl,carl@example.com\n", CSVOptions{Header: header, MaxRows: 1}},
+			{"Name,E-mail\nBob,bob-at-example\n\"Carl,carl@example.com\n", CSVOptions{Header: header, Options: Options{AbortEarly: true}}},
+		}
+		for _, tt := range malformed {
+			var parseErr *csv.ParseError
+			if _, err := ValidateCSV(strings.NewReader(tt.source), schema, tt.options); !errors.As(err, &parseErr) {
+				t.Errorf("%q: Actual = %v, Expected a csv.ParseError", tt.source, err)
+			}
+		}
+		stop := errors.New("stop")
+		_, err := ValidateCSV(strings.NewReader(source), schema, CSVOptions{
+			Header: header,
+			Valid:  func(row int, data DataObject) error { return stop },
+		})
+		if err != stop {
+			t.Errorf("Actual = %v, Expected = %v", err, stop)
+		}
+	})
+}
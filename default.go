@@ -0,0 +1,51 @@
+package validet
+
+import "reflect"
+
+// DefaultFunc computes the Default of a rule from the rest of the data.
+//
+// The Default of a rule is used in place of an absent (missing or null)
+// value, before any assertion runs, and is collected by Bind and Validated
+// as if it had been sent. It is either a static value of the type the rule
+// expects, e.g. a string for String or an NT for Numeric, or a DefaultFunc.
+// Typed slices such as []string or []DataObject are accepted for Slice and
+// SliceObject.
+//
+// The nested fields of an Object or SliceObject get their own defaults, so
+// an Object with a DataObject{} Default is filled with the defaults of its
+// fields.
+//
+// File and Files have no Default: an upload can't be made up on the server,
+// so an absent file stays absent and is only checked by the required rules.
+type DefaultFunc = func(look Lookup) any
+
+// applyDefault returns the default of the rule when the value is absent and
+// records it in the output.
+func applyDefault(def any, value any, params RuleContext) any {
+	if value != nil || def == nil {
+		return value
+	}
+	if fn, ok := def.(DefaultFunc); ok {
+		value = fn(params.Lookup)
+	} else {
+		value = def
+	}
+	value = defaultData(value)
+	if value != nil && params.Output != nil {
+		params.Output[params.Key] = value
+	}
+	return value
+}
+
+// defaultData converts typed slices to the []any holding decoded data.
+func defaultData(value any) any {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice || v.Type() == reflect.TypeOf([]any{}) {
+		return value
+	}
+	values := make([]any, v.Len())
+	for i := range values {
+		values[i] = v.Index(i).Interface()
+	}
+	return values
+}
@@ -0,0 +1,109 @@
+package validet
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Default(t *testing.T) {
+	schema := SchemaRules{
+		"page_size": Numeric[int]{Required: true, Max: 100, Default: 20},
+		"locale": String{Default: func(look Lookup) any {
+			if look("country").String() == "ID" {
+				return "id"
+			}
+			return "en"
+		}},
+		"country": String{},
+		"tags":    Slice[string]{Default: []string{"new"}},
+		"filter": Object{Default: DataObject{}, Item: SchemaObject{
+			"status": String{In: []string{"open", "closed"}, Default: "open"},
+			"mine":   Boolean{Default: false},
+		}},
+		"sort": SliceObject{Item: SchemaObject{
+			"field":     String{Required: true},
+			"direction": String{Default: "asc"},
+		}},
+	}
+
+	t.Run("it should bind the defaults of absent values", func(t *testing.T) {
+		data := DataObject{
+			"country": "ID",
+			"sort":    []any{DataObject{"field": "name"}, DataObject{"field": "age", "direction": "desc"}},
+		}
+		output, bags, err := Bind[DataObject](data, schema, Options{})
+		if err != nil {
+			t.Fatalf("unexpected errors %v", bags.Errors)
+		}
+		expected := DataObject{
+			"page_size": 20,
+			"locale":    "id",
+			"country":   "ID",
+			"tags":      []any{"new"},
+			"filter":    DataObject{"status": "open", "mine": false},
+			"sort": []any{
+				DataObject{"field": "name", "direction": "asc"},
+				DataObject{"field": "age", "direction": "desc"},
+			},
+		}
+		if !reflect.DeepEqual(output, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", output, expected)
+		}
+		if _, ok := data["page_size"]; ok {
+			t.Errorf("the data was modified: %#v", data)
+		}
+	})
+
+	t.Run("it should validate the defaults like sent values", func(t *testing.T) {
+		bags, _ := ValidateJSON([]byte(`{"page_size": null}`), SchemaRules{
+			"page_size": Numeric[float64]{Required: true, Default: 500.0, Max: 100},
+			"status":    String{Default: 1},
+		}, Options{})
+		if len(bags.Errors["page_size"]) != 1 || len(bags.Errors["status"]) != 1 {
+			t.Errorf("Actual = %v, Expected a page_size and a status error", bags.Errors)
+		}
+	})
+
+	t.Run("it should build typed defaults", func(t *testing.T) {
+		rules, err := BuildSchema(
+			Key("page_size", Integer().Default(20)),
+			Key("tags", List[string]().Default([]string{"new"})),
+			Key("locale", Str().DefaultFunc(func(look Lookup) string { return look("lang").String() })),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output, _, err := Bind[DataObject](DataObject{"lang": "id"}, SchemaRules{
+			"page_size": rules[0].Rule,
+			"tags":      rules[1].Rule,
+			"locale":    rules[2].Rule,
+		}, Options{})
+		expected := DataObject{"page_size": 20, "tags": []any{"new"}, "locale": "id"}
+		if err != nil || !reflect.DeepEqual(output, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", output, expected)
+		}
+	})
+
+	t.Run("it should export and import static defaults", func(t *testing.T) {
+		document := JSONSchema(SchemaRules{
+			"page_size": Numeric[float64]{Default: 20.0},
+			"locale":    String{Default: func(look Lookup) any { return "en" }},
+		})
+		properties := document["properties"].(DataObject)
+		if properties["page_size"].(DataObject)["default"] != 20.0 {
+			t.Errorf("Actual = %#v, Expected a default", properties["page_size"])
+		}
+		if !reflect.DeepEqual(properties["locale"].(DataObject)[JSONSchemaUnsupported], []string{"default"}) {
+			t.Errorf("Actual = %#v, Expected an unsupported default", properties["locale"])
+		}
+
+		rules, err := LoadJSONSchema(strings.NewReader(`{"type": "object", "properties": {"page_size": {"type": "integer", "default": 20}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rules["page_size"].(Numeric[float64]).Default != 20.0 {
+			t.Errorf("Actual = %#v, Expected a default", rules["page_size"])
+		}
+	})
+}
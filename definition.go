@@ -0,0 +1,743 @@
+package validet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Definition is a declarative schema that can be stored as JSON or YAML and
+// turned into rules at runtime with Rules. A YAML definition looks like:
+//
+//	fields:
+//	  name:
+//	    type: string
+//	    required: true
+//	    max: 50
+//	    messages:
+//	      required: Please tell us your name
+//	  company:
+//	    type: object
+//	    required_if: {field: type, value: company}
+//	    fields:
+//	      tax_id: {type: string, custom: tax_id}
+//	  tags:
+//	    type: slice
+//	    of: string
+//
+// Definitions are plain data: loading and saving one with the functions
+// below keeps it intact, including the order of the fields, so tools can
+// edit definitions they load. YAML anchors, aliases and merge keys may be
+// used to share field definitions.
+type Definition struct {
+	Fields FieldDefinitions `json:"fields"`
+}
+
+// FieldDefinitions lists the fields of a definition in declaration order.
+// JSON and YAML documents hold it as a mapping from field name to field
+// definition, whose key order is kept.
+type FieldDefinitions []DefinitionField
+
+// DefinitionField is a field definition with the name of its field.
+type DefinitionField struct {
+	Key   string
+	Field FieldDefinition
+}
+
+// Get returns the definition of the field named key.
+func (f FieldDefinitions) Get(key string) (FieldDefinition, bool) {
+	for _, field := range f {
+		if field.Key == key {
+			return field.Field, true
+		}
+	}
+	return FieldDefinition{}, false
+}
+
+func (f FieldDefinitions) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, field := range f {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Field)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func (f *FieldDefinitions) UnmarshalJSON(source []byte) error {
+	node, err := parseYAML(source)
+	if err != nil {
+		return err
+	}
+	return decodeDefinition(reflect.ValueOf(f).Elem(), node, "")
+}
+
+func (f FieldDefinitions) MarshalYAML() (any, error) {
+	return yamlNode(f)
+}
+
+func (f *FieldDefinitions) UnmarshalYAML(node *yaml.Node) error {
+	return decodeDefinition(reflect.ValueOf(f).Elem(), node, "")
+}
+
+// FieldDefinition declares the rule of a single field. Type is one of
+// string, boolean, int, int32, int64, uint, uint32, uint64, float32,
+// float64, slice (with Of naming the item type), object or slice_object
+// (with Fields). The other fields mirror the fields of the rule of that
+// type; Custom names a func registered with RegisterCustom and Messages
+// overrides the rule messages by rule code, e.g. "min" or "required_if".
+// Transform lists the transformers of a string in order, either built-in
+// ones such as trim or lowercase or a func(string) string registered with
+// RegisterCustom.
+type FieldDefinition struct {
+	Type           string               `json:"type"`
+	Of             string               `json:"of,omitempty"`
+	Required       bool                 `json:"required,omitempty"`
+	RequiredIf     *ConditionDefinition `json:"required_if,omitempty"`
+	RequiredUnless *ConditionDefinition `json:"required_unless,omitempty"`
+	Min            int                  `json:"min,omitempty"`
+	Max            int                  `json:"max,omitempty"`
+	MinDigits      int                  `json:"min_digits,omitempty"`
+	MaxDigits      int                  `json:"max_digits,omitempty"`
+	Regex          string               `json:"regex,omitempty"`
+	NotRegex       string               `json:"not_regex,omitempty"`
+	In             []any                `json:"in,omitempty"`
+	NotIn          []any                `json:"not_in,omitempty"`
+	Email          bool                 `json:"email,omitempty"`
+	Alpha          bool                 `json:"alpha,omitempty"`
+	AlphaNumeric   bool                 `json:"alpha_numeric,omitempty"`
+	Url            []string             `json:"url,omitempty"`
+	Custom         string               `json:"custom,omitempty"`
+	Transform      []string             `json:"transform,omitempty"`
+	Messages       map[string]string    `json:"messages,omitempty"`
+	Fields         FieldDefinitions     `json:"fields,omitempty"`
+}
+
+// ConditionDefinition is the condition of required_if and required_unless.
+type ConditionDefinition struct {
+	Field string `json:"field"`
+	Value any    `json:"value"`
+}
+
+// DefinitionError reports a malformed definition: the file it was read
+// from, if any, the line and column of the syntax error or of the entry
+// that doesn't decode, when known, and the path of the offending entry,
+// e.g. "fields.address.fields.city.min".
+type DefinitionError struct {
+	File   string
+	Line   int
+	Column int
+	Path   string
+	Err    error
+}
+
+func (e *DefinitionError) Error() string {
+	var b strings.Builder
+	b.WriteString("validet: schema")
+	if e.File != "" {
+		b.WriteString(" " + e.File)
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&b, ":%d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&b, ":%d", e.Column)
+		}
+	}
+	if e.Path != "" {
+		b.WriteString(": " + e.Path)
+	}
+	return b.String() + ": " + e.Err.Error()
+}
+
+func (e *DefinitionError) Unwrap() error {
+	return e.Err
+}
+
+var customs = struct {
+	sync.RWMutex
+	funcs map[string]any
+}{funcs: map[string]any{}}
+
+// RegisterCustom registers a Custom func under a name definitions can refer
+// to. The func must have the signature of the Custom field of the rules
+// using it, e.g. func(v string, path PathKey, look Lookup) error for String.
+func RegisterCustom(name string, fn any) {
+	customs.Lock()
+	defer customs.Unlock()
+	customs.funcs[name] = fn
+}
+
+func definitionCustom[F any](name string) (F, error) {
+	var fn F
+	if name == "" {
+		return fn, nil
+	}
+	customs.RLock()
+	registered, ok := customs.funcs[name]
+	customs.RUnlock()
+	if !ok {
+		return fn, fmt.Errorf("custom %q is not registered", name)
+	}
+	if fn, ok = registered.(F); !ok {
+		return fn, fmt.Errorf("custom %q is a %T, want %T", name, registered, fn)
+	}
+	return fn, nil
+}
+
+// ParseDefinition reads a JSON or YAML definition. Documents starting with
+// "{" must be valid JSON.
+func ParseDefinition(source []byte) (Definition, error) {
+	if trimmed := bytes.TrimSpace(source); len(trimmed) > 0 && trimmed[0] == '{' {
+		var tree any
+		if err := json.Unmarshal(source, &tree); err != nil {
+			definitionErr := &DefinitionError{Err: err}
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				definitionErr.Line, definitionErr.Column = sourcePosition(source, syntaxErr.Offset)
+			}
+			return Definition{}, definitionErr
+		}
+	}
+	node, err := parseYAML(source)
+	if err != nil {
+		return Definition{}, err
+	}
+	var definition Definition
+	if err := decodeDefinition(reflect.ValueOf(&definition).Elem(), node, ""); err != nil {
+		return Definition{}, err
+	}
+	return definition, nil
+}
+
+// sourcePosition returns the line and column of the byte read last when a
+// decoder stopped after offset bytes.
+func sourcePosition(source []byte, offset int64) (int, int) {
+	if offset <= 0 || offset > int64(len(source)) {
+		return 0, 0
+	}
+	before := source[:offset-1]
+	return bytes.Count(before, []byte("\n")) + 1, len(before) - bytes.LastIndexByte(before, '\n')
+}
+
+// LoadDefinition reads a JSON or YAML definition, like ParseDefinition.
+func LoadDefinition(r io.Reader) (Definition, error) {
+	source, err := io.ReadAll(r)
+	if err != nil {
+		return Definition{}, &DefinitionError{Err: err}
+	}
+	return ParseDefinition(source)
+}
+
+// LoadDefinitionFile reads the JSON or YAML definition stored in a file.
+func LoadDefinitionFile(path string) (Definition, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return Definition{}, &DefinitionError{File: path, Err: err}
+	}
+	definition, err := ParseDefinition(source)
+	return definition, withDefinitionFile(err, path)
+}
+
+// LoadSchemaFile reads the definition stored in a file and returns its
+// rules.
+func LoadSchemaFile(path string) (SchemaRules, error) {
+	definition, err := LoadDefinitionFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := definition.Rules()
+	return rules, withDefinitionFile(err, path)
+}
+
+// SaveDefinitionFile writes a definition to a file, as YAML when the file
+// name ends with .yaml or .yml and as JSON otherwise.
+func SaveDefinitionFile(path string, definition Definition) error {
+	var source []byte
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		source, err = definition.YAML()
+	default:
+		source, err = definition.JSON()
+	}
+	if err != nil {
+		return &DefinitionError{File: path, Err: err}
+	}
+	if err := os.WriteFile(path, source, 0o644); err != nil {
+		return &DefinitionError{File: path, Err: err}
+	}
+	return nil
+}
+
+func withDefinitionFile(err error, path string) error {
+	var definitionErr *DefinitionError
+	if errors.As(err, &definitionErr) {
+		definitionErr.File = path
+	}
+	return err
+}
+
+// JSON encodes the definition as indented JSON.
+func (d Definition) JSON() ([]byte, error) {
+	source, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(source, '\n'), nil
+}
+
+// YAML encodes the definition as YAML.
+func (d Definition) YAML() ([]byte, error) {
+	return marshalYAML(d)
+}
+
+// Rules converts the definition into rules.
+func (d Definition) Rules() (SchemaRules, error) {
+	fields, err := d.OrderedRules()
+	if err != nil {
+		return nil, err
+	}
+	rules := SchemaRules{}
+	for _, field := range fields {
+		rules[field.Key] = field.Rule
+	}
+	return rules, nil
+}
+
+// OrderedRules converts the definition into rules evaluated in the order
+// the fields are declared.
+func (d Definition) OrderedRules() (OrderedRules, error) {
+	return definitionFields(d.Fields, "fields")
+}
+
+func definitionFields(fields FieldDefinitions, path string) (OrderedRules, error) {
+	rules := make(OrderedRules, 0, len(fields))
+	declared := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		fieldPath := path + "." + field.Key
+		if field.Key == "" {
+			return nil, &DefinitionError{Path: fieldPath, Err: errors.New("empty field name")}
+		}
+		if declared[field.Key] {
+			return nil, &DefinitionError{Path: fieldPath, Err: errors.New("duplicate field")}
+		}
+		declared[field.Key] = true
+		rule, err := field.Field.rule(fieldPath)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, Field{Key: field.Key, Rule: rule})
+	}
+	return rules, nil
+}
+
+// definitionSep separates list arguments in the specs built from a
+// definition, since the values themselves may contain commas or spaces.
+const definitionSep = "\x1f"
+
+func (f FieldDefinition) specs() []ruleSpec {
+	var specs []ruleSpec
+	add := func(name string, arg string) {
+		specs = append(specs, ruleSpec{name: name, arg: arg, sep: definitionSep, pos: -1})
+	}
+	list := func(values []any) string {
+		args := make([]string, len(values))
+		for i, v := range values {
+			args[i] = conditionString(v)
+		}
+		return strings.Join(args, definitionSep)
+	}
+	if f.Required {
+		add("required", "")
+	}
+	if f.RequiredIf != nil {
+		add("required_if", f.RequiredIf.Field+definitionSep+conditionString(f.RequiredIf.Value))
+	}
+	if f.RequiredUnless != nil {
+		add("required_unless", f.RequiredUnless.Field+definitionSep+conditionString(f.RequiredUnless.Value))
+	}
+	for _, n := range []struct {
+		name  string
+		value int
+	}{{"min", f.Min}, {"max", f.Max}, {"min_digits", f.MinDigits}, {"max_digits", f.MaxDigits}} {
+		if n.value != 0 {
+			add(n.name, fmt.Sprint(n.value))
+		}
+	}
+	if f.Regex != "" {
+		add("regex", f.Regex)
+	}
+	if f.NotRegex != "" {
+		add("not_regex", f.NotRegex)
+	}
+	if f.In != nil {
+		add("in", list(f.In))
+	}
+	if f.NotIn != nil {
+		add("not_in", list(f.NotIn))
+	}
+	if f.Email {
+		add("email", "")
+	}
+	if f.Alpha {
+		add("alpha", "")
+	}
+	if f.AlphaNumeric {
+		add("alpha_numeric", "")
+	}
+	if f.Url != nil {
+		add("url", strings.Join(f.Url, definitionSep))
+	}
+	return specs
+}
+
+func (f FieldDefinition) rule(path string) (Rule, error) {
+	fail := func(err error) (Rule, error) {
+		return nil, &DefinitionError{Path: path, Err: err}
+	}
+	if f.Of != "" && f.Type != "slice" {
+		return fail(errors.New(`"of" is only supported by slice`))
+	}
+	if f.Fields != nil && f.Type != "object" && f.Type != "slice_object" {
+		return fail(errors.New(`"fields" is only supported by object and slice_object`))
+	}
+	if f.Transform != nil && f.Type != "string" {
+		return fail(errors.New(`"transform" is only supported by string`))
+	}
+
+	specs := f.specs()
+	var rule Rule
+	var err error
+	switch f.Type {
+	case "string":
+		build := func(specs []ruleSpec) (String, error) {
+			rule, err := stringRule(specs)
+			if err != nil {
+				return rule, err
+			}
+			rule.Transform, err = definitionTransformers(f.Transform)
+			return rule, err
+		}
+		rule, err = definitionRule(specs, build, f, func(r *String, custom func(string, PathKey, Lookup) error) any {
+			r.Custom = custom
+			return &r.Message
+		})
+	case "boolean":
+		rule, err = definitionRule(specs, booleanRule, f, func(r *Boolean, custom func(bool, PathKey, Lookup) error) any {
+			r.Custom = custom
+			return &r.Message
+		})
+	case "int":
+		rule, err = definitionNumeric[int](specs, f)
+	case "int32":
+		rule, err = definitionNumeric[int32](specs, f)
+	case "int64":
+		rule, err = definitionNumeric[int64](specs, f)
+	case "uint":
+		rule, err = definitionNumeric[uint](specs, f)
+	case "uint32":
+		rule, err = definitionNumeric[uint32](specs, f)
+	case "uint64":
+		rule, err = definitionNumeric[uint64](specs, f)
+	case "float32":
+		rule, err = definitionNumeric[float32](specs, f)
+	case "float64":
+		rule, err = definitionNumeric[float64](specs, f)
+	case "slice":
+		switch f.Of {
+		case "string":
+			rule, err = definitionSlice[string](specs, f)
+		case "int":
+			rule, err = definitionSlice[int](specs, f)
+		case "int32":
+			rule, err = definitionSlice[int32](specs, f)
+		case "int64":
+			rule, err = definitionSlice[int64](specs, f)
+		case "uint":
+			rule, err = definitionSlice[uint](specs, f)
+		case "uint32":
+			rule, err = definitionSlice[uint32](specs, f)
+		case "uint64":
+			rule, err = definitionSlice[uint64](specs, f)
+		case "float32":
+			rule, err = definitionSlice[float32](specs, f)
+		case "float64":
+			rule, err = definitionSlice[float64](specs, f)
+		case "":
+			return fail(errors.New(`slice requires "of"`))
+		default:
+			return fail(fmt.Errorf("unknown item type %q", f.Of))
+		}
+	case "object":
+		fields, fieldsErr := definitionFields(f.Fields, path+".fields")
+		if fieldsErr != nil {
+			return nil, fieldsErr
+		}
+		build := func(specs []ruleSpec) (Object, error) {
+			rule, err := objectRule(specs, nil)
+			rule.Fields = fields
+			return rule, err
+		}
+		rule, err = definitionRule(specs, build, f, func(r *Object, custom func(DataObject, PathKey, Lookup) error) any {
+			r.Custom = custom
+			return &r.Message
+		})
+	case "slice_object":
+		fields, fieldsErr := definitionFields(f.Fields, path+".fields")
+		if fieldsErr != nil {
+			return nil, fieldsErr
+		}
+		build := func(specs []ruleSpec) (SliceObject, error) {
+			rule, err := sliceObjectRule(specs, nil)
+			rule.Fields = fields
+			return rule, err
+		}
+		rule, err = definitionRule(specs, build, f, func(r *SliceObject, custom func([]DataObject, PathKey, Lookup) error) any {
+			r.Custom = custom
+			return &r.Message
+		})
+	case "":
+		return fail(errors.New("missing type"))
+	default:
+		return fail(fmt.Errorf("unknown type %q", f.Type))
+	}
+	if err != nil {
+		return fail(err)
+	}
+	return rule, nil
+}
+
+// definitionRule builds a rule from its specs, then sets the Custom func
+// and the messages, which set returns a pointer to.
+func definitionRule[R Rule, F any](specs []ruleSpec, build func([]ruleSpec) (R, error), f FieldDefinition, set func(r *R, custom F) any) (Rule, error) {
+	rule, err := build(specs)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := definitionCustom[F](f.Custom)
+	if err != nil {
+		return nil, err
+	}
+	if err := definitionMessages(f.Messages, set(&rule, custom)); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// definitionTransformers resolves the transformer names of a definition,
+// looking up the names that are not built-in with RegisterCustom.
+func definitionTransformers(names []string) ([]Transformer, error) {
+	var fns []Transformer
+	for _, name := range names {
+		if name == "" {
+			return nil, errors.New("empty transformer name")
+		}
+		fn, ok := transformers[name]
+		if !ok {
+			custom, err := definitionCustom[func(string) string](name)
+			if err != nil {
+				return nil, err
+			}
+			fn = custom
+		}
+		fns = append(fns, fn)
+	}
+	return fns, nil
+}
+
+func definitionNumeric[NT NumericValue](specs []ruleSpec, f FieldDefinition) (Rule, error) {
+	return definitionRule(specs, numericRule[NT], f, func(r *Numeric[NT], custom func(NT, PathKey, Lookup) error) any {
+		r.Custom = custom
+		return &r.Message
+	})
+}
+
+func definitionSlice[T SliceValueType](specs []ruleSpec, f FieldDefinition) (Rule, error) {
+	return definitionRule(specs, sliceRule[T], f, func(r *Slice[T], custom func([]T, PathKey, Lookup) error) any {
+		r.Custom = custom
+		return &r.Message
+	})
+}
+
+// definitionMessages copies messages keyed by rule code, e.g. "required_if",
+// into the matching fields of a rule error message struct, e.g. RequiredIf.
+func definitionMessages(messages map[string]string, target any) error {
+	v := reflect.ValueOf(target).Elem()
+	keys := make([]string, 0, len(messages))
+	for key := range messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := ""
+		for _, part := range strings.Split(key, "_") {
+			if part != "" {
+				name += strings.ToUpper(part[:1]) + part[1:]
+			}
+		}
+		field := v.FieldByName(name)
+		if !field.IsValid() || field.Kind() != reflect.String {
+			return fmt.Errorf("unknown message %q", key)
+		}
+		field.SetString(messages[key])
+	}
+	return nil
+}
+
+// decodeDefinition fills dst from the YAML node of a JSON or YAML document,
+// reporting the path of the first value that doesn't fit.
+func decodeDefinition(dst reflect.Value, node *yaml.Node, path string) error {
+	node = yamlResolve(node)
+	fail := func(format string, args ...any) error {
+		return &DefinitionError{Line: node.Line, Column: node.Column, Path: path, Err: fmt.Errorf(format, args...)}
+	}
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		value := reflect.New(dst.Type().Elem())
+		if err := decodeDefinition(value.Elem(), node, path); err != nil {
+			return err
+		}
+		dst.Set(value)
+		return nil
+	case reflect.Struct:
+		pairs, ok := yamlPairs(node)
+		if !ok {
+			return fail("expected a mapping, got %s", definitionKind(node))
+		}
+		fields := map[string]int{}
+		for i := 0; i < dst.NumField(); i++ {
+			name, _, _ := strings.Cut(dst.Type().Field(i).Tag.Get("json"), ",")
+			fields[name] = i
+		}
+		for _, pair := range pairs {
+			key := pair[0].Value
+			i, ok := fields[key]
+			if !ok {
+				return &DefinitionError{Line: pair[0].Line, Column: pair[0].Column, Path: join(key), Err: errors.New("unknown key")}
+			}
+			if err := decodeDefinition(dst.Field(i), pair[1], join(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		pairs, ok := yamlPairs(node)
+		if !ok {
+			return fail("expected a mapping, got %s", definitionKind(node))
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), len(pairs))
+		for _, pair := range pairs {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeDefinition(elem, pair[1], join(pair[0].Value)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(pair[0].Value), elem)
+		}
+		dst.Set(m)
+		return nil
+	case reflect.Slice:
+		if dst.Type() == reflect.TypeOf(FieldDefinitions{}) {
+			pairs, ok := yamlPairs(node)
+			if !ok {
+				return fail("expected a mapping, got %s", definitionKind(node))
+			}
+			fields := make(FieldDefinitions, len(pairs))
+			for i, pair := range pairs {
+				fields[i].Key = pair[0].Value
+				if err := decodeDefinition(reflect.ValueOf(&fields[i].Field).Elem(), pair[1], join(pair[0].Value)); err != nil {
+					return err
+				}
+			}
+			dst.Set(reflect.ValueOf(fields))
+			return nil
+		}
+		if node.Kind != yaml.SequenceNode {
+			return fail("expected a list, got %s", definitionKind(node))
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(node.Content), len(node.Content))
+		for i, item := range node.Content {
+			if err := decodeDefinition(slice.Index(i), item, join(fmt.Sprint(i))); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.Interface:
+		if node.Kind == yaml.ScalarNode {
+			var value any
+			if err := node.Decode(&value); err == nil {
+				dst.Set(reflect.ValueOf(value))
+				return nil
+			}
+		}
+		return fail("expected a scalar, got %s", definitionKind(node))
+	case reflect.String:
+		if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" {
+			dst.SetString(node.Value)
+			return nil
+		}
+		return fail("expected a string, got %s", definitionKind(node))
+	case reflect.Bool:
+		// Decoding into a bool also accepts the yes, no, on and off of YAML 1.1.
+		var b bool
+		if node.Kind == yaml.ScalarNode && node.Decode(&b) == nil {
+			dst.SetBool(b)
+			return nil
+		}
+		return fail("expected a boolean, got %s", definitionKind(node))
+	case reflect.Int:
+		var n int
+		if node.Kind == yaml.ScalarNode && node.ShortTag() != "!!str" && node.Decode(&n) == nil {
+			dst.SetInt(int64(n))
+			return nil
+		}
+		return fail("expected an integer, got %s", definitionKind(node))
+	}
+	return fail("unsupported value %s", definitionKind(node))
+}
+
+func definitionKind(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	if node.ShortTag() == "!!str" {
+		return fmt.Sprintf("%q", node.Value)
+	}
+	return node.Value
+}
@@ -0,0 +1,227 @@
+package validet
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const definitionTestYAML = `# Registration form
+fields:
+  name:
+    type: string
+    required: true
+    max: 50
+    messages:
+      required: Please tell us your name
+  type:
+    type: string
+    in: [person, company]
+  company:
+    type: object
+    required_if: {field: type, value: company}
+    fields:
+      tax_id: {type: string, custom: tax_id}
+  age:
+    type: int
+    min: 17
+  tags:
+    type: slice
+    of: string
+    max: 3
+  items:
+    type: slice_object
+    min: 1
+    fields:
+      title:
+        type: string
+        required_unless:
+          field: type
+          value: "person"
+      note:
+        type: string
+        regex: '^[a-z ]+$'
+        messages:
+          regex: >-
+            Notes may only contain
+            lowercase letters
+`
+
+func Test_Definition(t *testing.T) {
+	taxID := func(v string, path PathKey, look Lookup) error {
+		if !strings.HasPrefix(v, "NPWP") {
+			return errors.New("invalid tax id")
+		}
+		return nil
+	}
+	RegisterCustom("tax_id", taxID)
+
+	t.Run("it should load the rules of a yaml definition", func(t *testing.T) {
+		definition, err := ParseDefinition([]byte(definitionTestYAML))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules, err := definition.Rules()
+		if err != nil {
+			t.Fatal(err)
+		}
+		company := rules["company"].(Object)
+		if company.Fields[0].Rule.(String).Custom == nil {
+			t.Fatal("expected the registered custom func")
+		}
+		company.Fields[0].Rule = String{}
+		rules["company"] = company
+
+		expected := SchemaRules{
+			"name":    String{Required: true, Max: 50, Message: StringErrorMessage{Required: "Please tell us your name"}},
+			"type":    String{In: []string{"person", "company"}},
+			"company": Object{RequiredIf: &RequiredIf{FieldPath: "type", Value: "company"}, Fields: OrderedRules{{Key: "tax_id", Rule: String{}}}},
+			"age":     Numeric[int]{Min: 17},
+			"tags":    Slice[string]{Max: 3},
+			"items": SliceObject{Min: 1, Fields: OrderedRules{
+				{Key: "title", Rule: String{RequiredUnless: &RequiredUnless{FieldPath: "type", Value: "person"}}},
+				{Key: "note", Rule: String{Regex: "^[a-z ]+$", Message: StringErrorMessage{Regex: "Notes may only contain lowercase letters"}}},
+			}},
+		}
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rules, expected)
+		}
+	})
+
+	t.Run("it should validate with the loaded rules", func(t *testing.T) {
+		definition, _ := ParseDefinition([]byte(definitionTestYAML))
+		rules, _ := definition.Rules()
+		bags, _ := ValidateJSON([]byte(`{"type": "company", "company": {"tax_id": "123"}, "items": [{"title": "a", "note": "A"}]}`), rules, Options{})
+		expected := map[string][]string{
+			"name":           {"Please tell us your name"},
+			"company.tax_id": {"invalid tax id"},
+			"items.0.note":   {"Notes may only contain lowercase letters"},
+		}
+		if !reflect.DeepEqual(bags.Errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, expected)
+		}
+	})
+
+	t.Run("it should save what it loads", func(t *testing.T) {
+		definition, err := ParseDefinition([]byte(definitionTestYAML))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dir := t.TempDir()
+		for _, name := range []string{"form.yaml", "form.json"} {
+			path := filepath.Join(dir, name)
+			if err := SaveDefinitionFile(path, definition); err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := LoadDefinitionFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(loaded, definition) {
+				t.Errorf("%s: Actual = %#v, Expected = %#v", name, loaded, definition)
+			}
+		}
+	})
+
+	t.Run("it should report where a definition is malformed", func(t *testing.T) {
+		dir := t.TempDir()
+		tests := []struct {
+			source   string
+			expected string
+		}{
+			{"fields:\n  name:\n    type: string\n    min: three\n", `form.yaml:4:10: fields.name.min: expected an integer, got "three"`},
+			{"fields:\n  name:\n    type: string\n    minimum: 3\n", `form.yaml:4:5: fields.name.minimum: unknown key`},
+			{"fields:\n  name:\n    type: string\n   max: 3\n", `form.yaml:1: did not find expected key`},
+			{"fields:\n  age:\n    type: int\n    email: true\n", `form.yaml: fields.age: rule "email": rule is not supported for this type`},
+			{"fields:\n  user:\n    type: object\n    fields:\n      name: {type: text}\n", `form.yaml: fields.user.fields.name: unknown type "text"`},
+			{"fields:\n  name: {type: string, custom: slug}\n", `form.yaml: fields.name: custom "slug" is not registered`},
+			{"fields:\n  code: {type: int, custom: tax_id}\n", `form.yaml: fields.code: custom "tax_id" is a func(string, validet.PathKey, func(string) gjson.Result) error`},
+			{"fields:\n  name: {type: string, messages: {minimum: short}}\n", `form.yaml: fields.name: unknown message "minimum"`},
+			{`{"fields": {"name": {"type": "string",}}}`, `form.yaml:1:39: invalid character '}'`},
+			{"{\n  \"fields\": {\n    \"name\": {\"type\": \"string\" \"max\": 3}\n  }\n}", `form.yaml:3:31: invalid character '"'`},
+			{"{\"fields\": {\"name\": {\"type\": \"string\", \"max\": \"3\"}}}", `form.yaml:1:47: fields.name.max: expected an integer, got "3"`},
+		}
+		for _, tt := range tests {
+			path := filepath.Join(dir, "form.yaml")
+			os.WriteFile(path, []byte(tt.source), 0o644)
+			_, err := LoadSchemaFile(path)
+			var definitionErr *DefinitionError
+			if !errors.As(err, &definitionErr) || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Actual = %v, Expected to contain = %s", err, tt.expected)
+			}
+		}
+	})
+}
+
+func Test_DefinitionYAML(t *testing.T) {
+	t.Run("it should read anchors, aliases, merge keys and yes/no booleans", func(t *testing.T) {
+		source := "fields:\n" +
+			"  name: &text\n" +
+			"    type: string\n" +
+			"    required: yes\n" +
+			"    max: 50\n" +
+			"  nickname: *text\n" +
+			"  bio:\n" +
+			"    <<: *text\n" +
+			"    required: no\n" +
+			"    max:\t500\n"
+		definition, err := ParseDefinition([]byte(source))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules, err := definition.OrderedRules()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := OrderedRules{
+			{Key: "name", Rule: String{Required: true, Max: 50}},
+			{Key: "nickname", Rule: String{Required: true, Max: 50}},
+			{Key: "bio", Rule: String{Max: 500}},
+		}
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rules, expected)
+		}
+	})
+
+	t.Run("it should keep the order of the fields", func(t *testing.T) {
+		definition, err := ParseDefinition([]byte("fields:\n  zip: {type: string}\n  city: {type: string}\n  address:\n    type: object\n    fields:\n      street: {type: string}\n      number: {type: int}\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, encode := range []func() ([]byte, error){definition.YAML, definition.JSON} {
+			source, err := encode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			loaded, err := ParseDefinition(source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rules, _ := loaded.OrderedRules()
+			keys := []string{}
+			for _, field := range rules {
+				keys = append(keys, field.Key)
+			}
+			nested := []string{}
+			for _, field := range rules[2].Rule.(Object).Fields {
+				nested = append(nested, field.Key)
+			}
+			if !reflect.DeepEqual(keys, []string{"zip", "city", "address"}) || !reflect.DeepEqual(nested, []string{"street", "number"}) {
+				t.Errorf("Actual = %v %v, Expected the declaration order in %s", keys, nested, source)
+			}
+		}
+	})
+
+	t.Run("it should reject duplicate fields", func(t *testing.T) {
+		definition := Definition{Fields: FieldDefinitions{
+			{Key: "name", Field: FieldDefinition{Type: "string"}},
+			{Key: "name", Field: FieldDefinition{Type: "int"}},
+		}}
+		if _, err := definition.Rules(); err == nil || !strings.Contains(err.Error(), "fields.name: duplicate field") {
+			t.Errorf("Actual = %v, Expected a duplicate field error", err)
+		}
+	})
+}
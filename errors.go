@@ -1,27 +1,103 @@
 package validet
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
+const (
+	RuleRequired          = "required"
+	RuleRequiredIf        = "required_if"
+	RuleRequiredUnless    = "required_unless"
+	RuleType              = "type"
+	RuleMin               = "min"
+	RuleMax               = "max"
+	RuleMinDigits         = "min_digits"
+	RuleMaxDigits         = "max_digits"
+	RuleInteger           = "integer"
+	RuleGreaterThan       = "gt"
+	RuleLessThan          = "lt"
+	RuleMultipleOf        = "multiple_of"
+	RuleRegex             = "regex"
+	RuleNotRegex          = "not_regex"
+	RuleIn                = "in"
+	RuleNotIn             = "not_in"
+	RuleEmail             = "email"
+	RuleAlpha             = "alpha"
+	RuleAlphaNumeric      = "alpha_numeric"
+	RuleUrl               = "url"
+	RuleCustom            = "custom"
+	RuleMimes             = "mimes"
+	RuleImage             = "image"
+	RuleImageFormat       = "image_format"
+	RuleDimensions        = "dimensions"
+	RuleRatio             = "ratio"
+	RuleArchive           = "archive"
+	RuleArchiveEntries    = "archive_entries"
+	RuleArchiveSize       = "archive_size"
+	RuleArchiveRatio      = "archive_ratio"
+	RuleArchivePath       = "archive_path"
+	RuleArchiveExtensions = "archive_extensions"
+	RuleMaxTotalSize      = "max_total_size"
+	RuleDistinct          = "distinct"
+)
+
+// FieldError describes a single failed rule: where it failed (Path), which
+// rule failed (Rule, one of the Rule* constants or a code chosen by a custom
+// rule), the rule parameters, the offending value and the rendered message.
+//
+// A Custom func can return a FieldError to report its own rule code and
+// parameters instead of RuleCustom; its message is then rendered from the
+// template registered for that code, falling back to its Message.
+type FieldError struct {
+	Path    []string
+	Rule    string
+	Params  map[string]any
+	Value   any
+	Message string
+
+	messageKey string
+	template   string
+}
+
+// Key returns the dotted path used as the ErrorBag key.
+func (e FieldError) Key() string {
+	return strings.Join(e.Path, ".")
+}
+
+// Error renders the error in its message form.
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// ErrorBag holds the failed fields keyed by their dotted path. Errors keeps
+// the rendered messages and Fields keeps the structured errors. Keys lists
+// the failed paths in the order the fields were evaluated.
 type ErrorBag struct {
 	Errors map[string][]string
+	Fields map[string][]FieldError
+	Keys   []string
 	Status bool
 }
 
 func NewErrorBags() *ErrorBag {
 	return &ErrorBag{
 		Errors: make(map[string][]string),
+		Fields: make(map[string][]FieldError),
 	}
 }
 
 func (e *ErrorBag) add(key string, m string) {
-	e.Errors[key] = append(e.Errors[key], m)
+	e.append(key, []FieldError{{Path: strings.Split(key, "."), Message: m}})
 }
 
-func (e *ErrorBag) append(key string, msgs []string) {
-	if mv, ok := e.Errors[key]; ok {
-		e.Errors[key] = append(mv, msgs...)
-	} else {
-		e.Errors[key] = msgs
+func (e *ErrorBag) append(key string, errs []FieldError) {
+	if _, ok := e.Errors[key]; !ok && len(errs) > 0 {
+		e.Keys = append(e.Keys, key)
+	}
+	for _, fe := range errs {
+		e.Errors[key] = append(e.Errors[key], fe.Message)
+		e.Fields[key] = append(e.Fields[key], fe)
 	}
 }
 
@@ -31,6 +107,8 @@ var StringValidationError = errors.New("string validation failed")
 var NumericValidationError = errors.New("numeric validation failed")
 var SliceValidationError = errors.New("slice validation failed")
 var FileValidationError = errors.New("file validation failed")
+var FilesValidationError = errors.New("files validation failed")
 var BooleanValidationError = errors.New("boolean validation failed")
 
 var ErrorRequiredField = errors.New("field cannot be empty")
+var ErrInvalidJSON = errors.New("source must be a valid JSON object")
@@ -1,35 +1,119 @@
 package validet
 
 import (
-	"fmt"
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
 	"mime/multipart"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/tidwall/gjson"
 )
 
 type FileErrorMessage struct {
-	Required       string
-	RequiredIf     string
-	RequiredUnless string
-	Max            string
-	Min            string
-	Mimes          string
-	Custom         string
+	Required          string
+	RequiredIf        string
+	RequiredUnless    string
+	Max               string
+	Min               string
+	Mimes             string
+	Image             string
+	ImageFormat       string
+	Dimensions        string
+	Ratio             string
+	Archive           string
+	ArchiveEntries    string
+	ArchiveSize       string
+	ArchiveRatio      string
+	ArchivePath       string
+	ArchiveExtensions string
+	Custom            string
 }
 
+// File validates an uploaded file, a *multipart.FileHeader such as the ones
+// of http.Request.MultipartForm. Min and Max limit the size in bytes when
+// they are set. Mimes lists the allowed content types, e.g. "image/png" or
+// "image/*", or extensions, e.g. "png" or ".pdf". The content type is sniffed
+// from the leading bytes of the file with http.DetectContentType rather than
+// trusted from the client, and extensions match the content type their files
+// are sniffed as. Image and Archive, when set, only accept the images and the
+// ZIP archives they describe.
+//
+// The formats http.DetectContentType can't tell apart are sniffed further
+// when Mimes names them exactly, not through a wildcard: docx, xlsx and pptx
+// are ZIP archives whose [Content_Types].xml declares the main part of that
+// kind of document, svg is XML with an svg root element, json is text
+// holding a single JSON value and csv is text whose rows all parse with the
+// same number of fields, which includes any plain text of a single column.
+// These files still match "application/zip" or "text/plain".
 type File struct {
 	Required       bool
 	RequiredIf     *RequiredIf
 	RequiredUnless *RequiredUnless
 	Max            int64
 	Min            int64
-	Mimes          string
+	Mimes          []string
+	Image          *Image
+	Archive        *Archive
 	Custom         func(v multipart.FileHeader, path PathKey, look Lookup) error
 	Message        FileErrorMessage
 }
 
-func (s File) validate(source []byte, value any, params RuleParams) ([]string, error) {
-	var bags []string
+// fileTypes maps the extensions accepted by File.Mimes to the content type
+// sniffed from their files, see sniffContentTypes. Extensions missing here
+// are resolved with mime.TypeByExtension.
+var fileTypes = map[string]string{
+	"png":   "image/png",
+	"jpg":   "image/jpeg",
+	"jpeg":  "image/jpeg",
+	"gif":   "image/gif",
+	"webp":  "image/webp",
+	"bmp":   "image/bmp",
+	"ico":   "image/x-icon",
+	"pdf":   "application/pdf",
+	"zip":   "application/zip",
+	"docx":  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"pptx":  "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"gz":    "application/x-gzip",
+	"rar":   "application/x-rar-compressed",
+	"txt":   "text/plain",
+	"csv":   "text/csv",
+	"json":  "application/json",
+	"svg":   "image/svg+xml",
+	"html":  "text/html",
+	"htm":   "text/html",
+	"xml":   "text/xml",
+	"mp3":   "audio/mpeg",
+	"wav":   "audio/wave",
+	"ogg":   "application/ogg",
+	"mp4":   "video/mp4",
+	"webm":  "video/webm",
+	"avi":   "video/avi",
+	"woff":  "font/woff",
+	"woff2": "font/woff2",
+	"ttf":   "font/ttf",
+	"otf":   "font/otf",
+	"wasm":  "application/wasm",
+}
+
+func (s File) IsMyTypeOf(schema any) bool {
+	return reflect.TypeOf(schema).Kind() == reflect.Struct && reflect.TypeOf(schema) == reflect.TypeOf(File{})
+}
+
+func (s File) Process(params RuleContext) ([]FieldError, error) {
+	return s.Validate(params.OriginalData, params.Value(), params)
+}
+
+func (s File) Validate(source []byte, value any, params RuleContext) ([]FieldError, error) {
+	var bags []FieldError
 	key := params.Key
 	option := params.Option
 
@@ -62,8 +146,20 @@ func (s File) validate(source []byte, value any, params RuleParams) ([]string, e
 			return bags, err
 		}
 
+		if err := s.assertMimes(key, parsedValue, &bags); option.AbortEarly && err != nil {
+			return bags, err
+		}
+
+		if err := s.assertImage(key, parsedValue, &bags, option); option.AbortEarly && err != nil {
+			return bags, err
+		}
+
+		if err := s.assertArchive(key, parsedValue, &bags, option); option.AbortEarly && err != nil {
+			return bags, err
+		}
+
 		if s.Custom != nil {
-			if err := s.assertCustomValidation(s.Custom, source, parsedValue, PathKey{
+			if err := s.assertCustomValidation(s.Custom, source, *parsedValue, PathKey{
 				Previous: params.PathKey,
 				Current:  params.Key,
 			}, &bags); option.AbortEarly && err != nil {
@@ -80,12 +176,14 @@ func (s File) validate(source []byte, value any, params RuleParams) ([]string, e
 
 }
 
-func (s File) assertRequired(key string, value any, bags *[]string) error {
+func (s File) assertRequired(key string, value any, bags *[]FieldError) error {
 	if s.Required {
 		if value == nil {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return FileValidationError
@@ -94,26 +192,34 @@ func (s File) assertRequired(key string, value any, bags *[]string) error {
 	return nil
 }
 
-func (s File) assertType(key string, value any, bags *[]string) (multipart.FileHeader, error) {
-	if parsedValue, ok := value.(multipart.FileHeader); ok {
-		return parsedValue, nil
+func (s File) assertType(key string, value any, bags *[]FieldError) (*multipart.FileHeader, error) {
+	switch parsedValue := value.(type) {
+	case *multipart.FileHeader:
+		if parsedValue != nil {
+			return parsedValue, nil
+		}
+	case multipart.FileHeader:
+		return &parsedValue, nil
 	}
 
 	appendErrorBags(
 		bags,
-		fmt.Sprintf("%s must be a type of file", key),
+		key,
+		FieldError{Rule: RuleType, Params: map[string]any{"type": "file"}, Value: value},
+		"type.file",
 		s.Message.Required,
 	)
-	return multipart.FileHeader{}, FileValidationError
+	return nil, FileValidationError
 }
 
-func (s File) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]string) error {
+func (s File) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]FieldError) error {
 	if s.RequiredIf != nil && value == nil {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredIf.FieldPath)
-		if comparedValue.String() == s.RequiredIf.Value {
+		if s.RequiredIf.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+				"required_if",
 				s.Message.RequiredIf,
 			)
 			return FileValidationError
@@ -122,13 +228,14 @@ func (s File) assertRequiredIf(jsonSource []byte, key string, value any, bags *[
 	return nil
 }
 
-func (s File) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]string) error {
+func (s File) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]FieldError) error {
 	if s.RequiredUnless != nil && value == nil {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredUnless.FieldPath)
-		if comparedValue.String() != s.RequiredUnless.Value {
+		if !s.RequiredUnless.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+				"required_unless",
 				s.Message.RequiredUnless,
 			)
 			return FileValidationError
@@ -137,11 +244,13 @@ func (s File) assertRequiredUnless(jsonSource []byte, key string, value any, bag
 	return nil
 }
 
-func (s File) assertMin(key string, value multipart.FileHeader, bags *[]string) error {
-	if value.Size < s.Min {
+func (s File) assertMin(key string, value *multipart.FileHeader, bags *[]FieldError) error {
+	if s.Min > 0 && value.Size < s.Min {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s size must be at minimum %d", key, s.Min),
+			key,
+			FieldError{Rule: RuleMin, Params: map[string]any{"min": s.Min}, Value: value},
+			"min.file",
 			s.Message.Min,
 		)
 		return FileValidationError
@@ -149,11 +258,13 @@ func (s File) assertMin(key string, value multipart.FileHeader, bags *[]string)
 	return nil
 }
 
-func (s File) assertMax(key string, value multipart.FileHeader, bags *[]string) error {
-	if value.Size > s.Max {
+func (s File) assertMax(key string, value *multipart.FileHeader, bags *[]FieldError) error {
+	if s.Max > 0 && value.Size > s.Max {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s size must be at maximum %d", key, s.Max),
+			key,
+			FieldError{Rule: RuleMax, Params: map[string]any{"max": s.Max}, Value: value},
+			"max.file",
 			s.Message.Max,
 		)
 		return FileValidationError
@@ -161,14 +272,214 @@ func (s File) assertMax(key string, value multipart.FileHeader, bags *[]string)
 	return nil
 }
 
-func (s File) assertCustomValidation(fc func(v multipart.FileHeader, path PathKey, look Lookup) error, jsonSource []byte, value multipart.FileHeader, path PathKey, bags *[]string) error {
+func (s File) assertMimes(key string, value *multipart.FileHeader, bags *[]FieldError) error {
+	if len(s.Mimes) == 0 {
+		return nil
+	}
+	var allowed []string
+	for _, mime := range s.Mimes {
+		if contentType, ok := fileType(mime); ok {
+			allowed = append(allowed, contentType)
+		}
+	}
+	if contentTypes, err := sniffContentTypes(value, allowed); err == nil {
+		for _, contentType := range contentTypes {
+			for _, a := range allowed {
+				if matchContentType(a, contentType) {
+					return nil
+				}
+			}
+		}
+	}
+	appendErrorBags(
+		bags,
+		key,
+		FieldError{Rule: RuleMimes, Params: map[string]any{"mimes": s.Mimes}, Value: value},
+		RuleMimes,
+		s.Message.Mimes,
+	)
+	return FileValidationError
+}
+
+// fileType resolves an entry of Mimes to a content type, which may end with
+// a "/*" wildcard.
+func fileType(entry string) (string, bool) {
+	entry = strings.ToLower(strings.TrimSpace(entry))
+	if strings.Contains(entry, "/") {
+		return entry, true
+	}
+	ext := strings.TrimPrefix(entry, ".")
+	if contentType, ok := fileTypes[ext]; ok {
+		return contentType, true
+	}
+	if contentType, _, err := mime.ParseMediaType(mime.TypeByExtension("." + ext)); err == nil {
+		return contentType, true
+	}
+	return "", false
+}
+
+func matchContentType(allowed string, contentType string) bool {
+	if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+		return strings.HasPrefix(contentType, prefix+"/")
+	}
+	return allowed == contentType
+}
+
+// sniffContentTypes detects the content type of the file from its first 512
+// bytes, without its parameters, followed by the more specific types of the
+// contentSniffers refining it that are allowed.
+func sniffContentTypes(header *multipart.FileHeader, allowed []string) ([]string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if err != nil {
+		return nil, err
+	}
+	contentTypes := []string{contentType}
+	for _, sniffer := range contentSniffers {
+		if !slices.Contains(sniffer.detected, contentType) || !slices.Contains(allowed, sniffer.contentType) {
+			continue
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if sniffer.match(file, header.Size) {
+			contentTypes = append(contentTypes, sniffer.contentType)
+		}
+	}
+	return contentTypes, nil
+}
+
+// contentSniffers recognize the formats http.DetectContentType reports as
+// one of the detected types.
+var contentSniffers = []struct {
+	contentType string
+	detected    []string
+	match       func(file multipart.File, size int64) bool
+}{
+	{fileTypes["docx"], []string{"application/zip"}, isOfficeDocument(fileTypes["docx"])},
+	{fileTypes["xlsx"], []string{"application/zip"}, isOfficeDocument(fileTypes["xlsx"])},
+	{fileTypes["pptx"], []string{"application/zip"}, isOfficeDocument(fileTypes["pptx"])},
+	{fileTypes["svg"], []string{"text/xml", "text/plain", "text/html"}, isSVG},
+	{fileTypes["json"], []string{"text/plain"}, isJSON},
+	{fileTypes["csv"], []string{"text/plain"}, isCSV},
+}
+
+// isOfficeDocument matches the Office Open XML archives whose
+// [Content_Types].xml declares a main part of the given document type.
+func isOfficeDocument(contentType string) func(file multipart.File, size int64) bool {
+	return func(file multipart.File, size int64) bool {
+		reader, err := zip.NewReader(file, size)
+		if reader == nil {
+			return false
+		}
+		if err != nil && !errors.Is(err, zip.ErrInsecurePath) {
+			return false
+		}
+		for _, entry := range reader.File {
+			if entry.Name != "[Content_Types].xml" {
+				continue
+			}
+			content, err := entry.Open()
+			if err != nil {
+				return false
+			}
+			defer content.Close()
+			var types struct {
+				Overrides []struct {
+					ContentType string `xml:"ContentType,attr"`
+				} `xml:"Override"`
+			}
+			if err := xml.NewDecoder(io.LimitReader(content, 1<<20)).Decode(&types); err != nil {
+				return false
+			}
+			for _, override := range types.Overrides {
+				if override.ContentType == contentType+".main+xml" {
+					return true
+				}
+			}
+			return false
+		}
+		return false
+	}
+}
+
+// isSVG matches the XML documents whose root element is svg.
+func isSVG(file multipart.File, _ int64) bool {
+	decoder := xml.NewDecoder(file)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			return element.Name.Local == "svg"
+		}
+	}
+}
+
+// isJSON matches the files holding a single JSON value, without reading
+// them into memory.
+func isJSON(file multipart.File, _ int64) bool {
+	decoder := json.NewDecoder(file)
+	depth, values := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return values == 1 && depth == 0
+		}
+		if err != nil || values > 0 {
+			return false
+		}
+		if delim, ok := token.(json.Delim); ok {
+			if delim == '{' || delim == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			values++
+		}
+	}
+}
+
+// isCSV matches the files whose rows all have the same number of fields.
+func isCSV(file multipart.File, _ int64) bool {
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+	rows := 0
+	for {
+		_, err := reader.Read()
+		if err == io.EOF {
+			return rows > 0
+		}
+		if err != nil {
+			return false
+		}
+		rows++
+	}
+}
+
+func (s File) assertCustomValidation(fc func(v multipart.FileHeader, path PathKey, look Lookup) error, jsonSource []byte, value multipart.FileHeader, path PathKey, bags *[]FieldError) error {
 	err := fc(value, path, func(k string) gjson.Result {
 		return gjson.GetBytes(jsonSource, k)
 	})
 	if err != nil {
+		fe, messageKey := customFieldError(err, value)
 		appendErrorBags(
 			bags,
-			err.Error(),
+			path.Current,
+			fe,
+			messageKey,
 			s.Message.Custom,
 		)
 		return FileValidationError
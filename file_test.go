@@ -0,0 +1,145 @@
+package validet
+
+import (
+	"archive/zip"
+	"bytes"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// fileHeader builds the header of an uploaded file like a server parsing a
+// multipart body would.
+func fileHeader(t *testing.T, filename string, contentType string, content []byte) *multipart.FileHeader {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	header.Set("Content-Type", contentType)
+	part, _ := writer.CreatePart(header)
+	part.Write(content)
+	writer.Close()
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+func Test_File(t *testing.T) {
+	png := fileHeader(t, "avatar.png", "image/png", pngHeader)
+	disguised := fileHeader(t, "avatar.png", "image/png", []byte("#!/bin/sh\necho hi\n"))
+
+	validate := func(rule File, value any) map[string][]string {
+		schema := NewSchema(DataObject{"avatar": value}, SchemaRules{"avatar": rule}, Options{})
+		bags, _ := schema.Validate()
+		return bags.Errors
+	}
+
+	t.Run("it should accept files of the allowed types", func(t *testing.T) {
+		for _, mimes := range [][]string{{"png"}, {".PNG"}, {"image/png"}, {"image/*"}, {"pdf", "png"}} {
+			if errors := validate(File{Required: true, Mimes: mimes}, png); len(errors) > 0 {
+				t.Errorf("%v: Actual = %v, Expected no errors", mimes, errors)
+			}
+		}
+	})
+
+	t.Run("it should sniff the content instead of trusting the client", func(t *testing.T) {
+		errors := validate(File{Mimes: []string{"png", "jpg"}}, disguised)
+		expected := map[string][]string{"avatar": {"avatar must be a file of type png, jpg"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should sniff the formats sharing a detected content type", func(t *testing.T) {
+		archive := func(entries map[string]string) []byte {
+			var buf bytes.Buffer
+			writer := zip.NewWriter(&buf)
+			for name, content := range entries {
+				w, _ := writer.Create(name)
+				w.Write([]byte(content))
+			}
+			writer.Close()
+			return buf.Bytes()
+		}
+		contentTypes := func(main string) string {
+			return `<?xml version="1.0"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+				`<Default Extension="xml" ContentType="application/xml"/>` +
+				`<Override PartName="/main.xml" ContentType="application/vnd.openxmlformats-officedocument.` + main + `.main+xml"/></Types>`
+		}
+		docx := fileHeader(t, "report.docx", "application/octet-stream", archive(map[string]string{"[Content_Types].xml": contentTypes("wordprocessingml.document")}))
+		xlsx := fileHeader(t, "report.xlsx", "application/octet-stream", archive(map[string]string{"[Content_Types].xml": contentTypes("spreadsheetml.sheet")}))
+		zipped := fileHeader(t, "report.docx", "application/octet-stream", archive(map[string]string{"report.txt": "hello"}))
+		svg := fileHeader(t, "logo.svg", "image/svg+xml", []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"/>`))
+		inlineSVG := fileHeader(t, "logo.svg", "image/svg+xml", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><rect/></svg>`))
+		xmlFile := fileHeader(t, "logo.svg", "image/svg+xml", []byte(`<?xml version="1.0"?><note/>`))
+		jsonFile := fileHeader(t, "data.json", "application/json", []byte(`{"a": [1, 2]}`))
+		text := fileHeader(t, "data.json", "application/json", []byte(`{"a": 1} trailing`))
+		csvFile := fileHeader(t, "data.csv", "text/csv", []byte("a,b\n1,\"2,5\"\n"))
+		ragged := fileHeader(t, "data.csv", "text/csv", []byte("a,b\n1,2,3\n"))
+
+		tests := []struct {
+			mimes    []string
+			file     *multipart.FileHeader
+			accepted bool
+		}{
+			{[]string{"docx"}, docx, true},
+			{[]string{"docx"}, xlsx, false},
+			{[]string{"xlsx"}, xlsx, true},
+			{[]string{"pptx", "docx"}, zipped, false},
+			{[]string{"zip"}, docx, true},
+			{[]string{"svg"}, svg, true},
+			{[]string{"image/svg+xml"}, inlineSVG, true},
+			{[]string{"svg"}, xmlFile, false},
+			{[]string{"image/*"}, svg, false},
+			{[]string{"json"}, jsonFile, true},
+			{[]string{"json"}, text, false},
+			{[]string{"txt"}, text, true},
+			{[]string{"csv"}, csvFile, true},
+			{[]string{"csv"}, ragged, false},
+			{[]string{"csv"}, png, false},
+		}
+		for _, tt := range tests {
+			errors := validate(File{Mimes: tt.mimes}, tt.file)
+			if (len(errors) == 0) != tt.accepted {
+				t.Errorf("%v %s: Actual = %v, Expected accepted = %v", tt.mimes, tt.file.Filename, errors, tt.accepted)
+			}
+		}
+	})
+
+	t.Run("it should only check the size limits that are set", func(t *testing.T) {
+		if errors := validate(File{}, png); len(errors) > 0 {
+			t.Errorf("Actual = %v, Expected no errors", errors)
+		}
+		errors := validate(File{Min: 100, Max: 4}, png)
+		expected := map[string][]string{"avatar": {"avatar size must be at minimum 100", "avatar size must be at maximum 4"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should require a file", func(t *testing.T) {
+		errors := validate(File{Required: true}, nil)
+		if !reflect.DeepEqual(errors, map[string][]string{"avatar": {"avatar is required"}}) {
+			t.Errorf("Actual = %v", errors)
+		}
+		errors = validate(File{}, "avatar.png")
+		if !reflect.DeepEqual(errors, map[string][]string{"avatar": {"avatar must be a type of file"}}) {
+			t.Errorf("Actual = %v", errors)
+		}
+	})
+
+	t.Run("it should report unknown file types when compiled", func(t *testing.T) {
+		_, err := Compile(SchemaRules{"avatar": File{Mimes: []string{"png", "unknownext"}}})
+		if err == nil || !strings.Contains(err.Error(), `validet: avatar: unknown file type "unknownext"`) {
+			t.Errorf("Actual = %v", err)
+		}
+	})
+}
@@ -0,0 +1,347 @@
+package validet
+
+import (
+	"crypto/sha256"
+	"io"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+type FilesErrorMessage struct {
+	Required       string
+	RequiredIf     string
+	RequiredUnless string
+	Min            string
+	Max            string
+	MaxTotalSize   string
+	Distinct       string
+	Custom         string
+}
+
+// Files validates a list of uploaded files, such as the ones sent as
+// "attachments[]". Min and Max limit the number of files and MaxTotalSize
+// their combined size in bytes, when they are set. Every file is validated
+// with File, whose errors are keyed by the index of the file, e.g.
+// "attachments.2". Distinct rejects the files whose content is the same as
+// the one of a previous file.
+//
+// The value is a []*multipart.FileHeader or a []any of *multipart.FileHeader
+// such as the ones of the data built by ParseRequest; a single file is a
+// list of one.
+type Files struct {
+	Required       bool
+	RequiredIf     *RequiredIf
+	RequiredUnless *RequiredUnless
+	Min            int
+	Max            int
+	MaxTotalSize   int64
+	Distinct       bool
+	File           File
+	Custom         func(v []*multipart.FileHeader, path PathKey, look Lookup) error
+	Message        FilesErrorMessage
+}
+
+func (s Files) IsMyTypeOf(schema any) bool {
+	return reflect.TypeOf(schema).Kind() == reflect.Struct && reflect.TypeOf(schema) == reflect.TypeOf(Files{})
+}
+
+// Process validates the list, then every file of it.
+func (s Files) Process(params RuleContext) ([]FieldError, error) {
+	value := params.Value()
+	bags, err := s.Validate(params.OriginalData, value, params)
+	if err != nil {
+		return bags, err
+	}
+	headers, _ := fileHeaders(value)
+	var duplicates map[int]int
+	if s.Distinct {
+		duplicates = duplicateFiles(headers)
+	}
+
+	path := append(params.PathKey[:len(params.PathKey):len(params.PathKey)], params.Key)
+	name := strings.Join(path, ".")
+	for i, header := range headers {
+		index := strconv.Itoa(i)
+		ctx := params
+		ctx.DataKey = DataObject{index: header}
+		ctx.PathKey = path
+		ctx.Key = index
+		ctx.Schema = s.File
+		ctx.Output = nil
+		bags, err := s.File.Validate(params.OriginalData, header, ctx)
+		if first, ok := duplicates[i]; ok {
+			appendErrorBags(
+				&bags,
+				index,
+				FieldError{Rule: RuleDistinct, Params: map[string]any{"other": name + "." + strconv.Itoa(first)}, Value: header},
+				RuleDistinct,
+				s.Message.Distinct,
+			)
+			err = FilesValidationError
+		}
+		if err != nil {
+			appendFieldErrors(params.ErrorBags, append(path[:len(path):len(path)], index), params.Key+"."+index, bags, params.Option)
+			if params.Option.AbortEarly {
+				break
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Validate checks the list itself; the files are validated by Process.
+func (s Files) Validate(source []byte, value any, params RuleContext) ([]FieldError, error) {
+	var bags []FieldError
+	key := params.Key
+	option := params.Option
+
+	err := s.assertRequired(key, value, &bags)
+
+	if err != nil {
+		return bags, err
+	}
+
+	if err = s.assertRequiredIf(source, key, value, &bags); err != nil {
+		return bags, err
+	}
+
+	if err = s.assertRequiredUnless(source, key, value, &bags); err != nil {
+		return bags, err
+	}
+
+	if value != nil {
+		headers, err := s.assertType(key, value, &bags)
+
+		if err != nil {
+			return bags, err
+		}
+
+		if err := s.assertMin(key, headers, &bags); option.AbortEarly && err != nil {
+			return bags, err
+		}
+
+		if err := s.assertMax(key, headers, &bags); option.AbortEarly && err != nil {
+			return bags, err
+		}
+
+		if err := s.assertMaxTotalSize(key, headers, &bags); option.AbortEarly && err != nil {
+			return bags, err
+		}
+
+		if s.Custom != nil {
+			if err := s.assertCustomValidation(s.Custom, source, headers, PathKey{
+				Previous: params.PathKey,
+				Current:  params.Key,
+			}, &bags); option.AbortEarly && err != nil {
+				return bags, err
+			}
+		}
+	}
+
+	if len(bags) > 0 {
+		return bags, FilesValidationError
+	}
+
+	return bags, nil
+}
+
+// fileHeaders reads a list of files, or a single file as a list of one.
+func fileHeaders(value any) ([]*multipart.FileHeader, bool) {
+	switch v := value.(type) {
+	case []*multipart.FileHeader:
+		for _, header := range v {
+			if header == nil {
+				return nil, false
+			}
+		}
+		return v, true
+	case *multipart.FileHeader:
+		return []*multipart.FileHeader{v}, v != nil
+	case multipart.FileHeader:
+		return []*multipart.FileHeader{&v}, true
+	case []any:
+		headers := make([]*multipart.FileHeader, 0, len(v))
+		for _, item := range v {
+			switch header := item.(type) {
+			case *multipart.FileHeader:
+				if header == nil {
+					return nil, false
+				}
+				headers = append(headers, header)
+			case multipart.FileHeader:
+				headers = append(headers, &header)
+			default:
+				return nil, false
+			}
+		}
+		return headers, true
+	}
+	return nil, false
+}
+
+// duplicateFiles maps the index of every file whose content was already
+// seen to the index of its first occurrence. Files that can't be read are
+// left to the File rule.
+func duplicateFiles(headers []*multipart.FileHeader) map[int]int {
+	duplicates := map[int]int{}
+	seen := map[[sha256.Size]byte]int{}
+	for i, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			continue
+		}
+		hash := sha256.New()
+		_, err = io.Copy(hash, file)
+		file.Close()
+		if err != nil {
+			continue
+		}
+		var sum [sha256.Size]byte
+		copy(sum[:], hash.Sum(nil))
+		if first, ok := seen[sum]; ok {
+			duplicates[i] = first
+		} else {
+			seen[sum] = i
+		}
+	}
+	return duplicates
+}
+
+func (s Files) isEmpty(value any) bool {
+	if value == nil {
+		return true
+	}
+	headers, ok := fileHeaders(value)
+	return ok && len(headers) == 0
+}
+
+func (s Files) assertRequired(key string, value any, bags *[]FieldError) error {
+	if s.Required && s.isEmpty(value) {
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleRequired, Value: value},
+			"required",
+			s.Message.Required,
+		)
+		return FilesValidationError
+	}
+	return nil
+}
+
+func (s Files) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]FieldError) error {
+	if s.RequiredIf != nil && s.isEmpty(value) {
+		if s.RequiredIf.matches(jsonSource) {
+			appendErrorBags(
+				bags,
+				key,
+				FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+				"required_if",
+				s.Message.RequiredIf,
+			)
+			return FilesValidationError
+		}
+	}
+	return nil
+}
+
+func (s Files) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]FieldError) error {
+	if s.RequiredUnless != nil && s.isEmpty(value) {
+		if !s.RequiredUnless.matches(jsonSource) {
+			appendErrorBags(
+				bags,
+				key,
+				FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+				"required_unless",
+				s.Message.RequiredUnless,
+			)
+			return FilesValidationError
+		}
+	}
+	return nil
+}
+
+func (s Files) assertType(key string, value any, bags *[]FieldError) ([]*multipart.FileHeader, error) {
+	if headers, ok := fileHeaders(value); ok {
+		return headers, nil
+	}
+	appendErrorBags(
+		bags,
+		key,
+		FieldError{Rule: RuleType, Params: map[string]any{"type": "files"}, Value: value},
+		"type.files",
+		"",
+	)
+	return nil, FilesValidationError
+}
+
+func (s Files) assertMin(key string, headers []*multipart.FileHeader, bags *[]FieldError) error {
+	if s.Min > 0 && len(headers) < s.Min {
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleMin, Params: map[string]any{"min": s.Min}, Value: headers},
+			"min.files",
+			s.Message.Min,
+		)
+		return FilesValidationError
+	}
+	return nil
+}
+
+func (s Files) assertMax(key string, headers []*multipart.FileHeader, bags *[]FieldError) error {
+	if s.Max > 0 && len(headers) > s.Max {
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleMax, Params: map[string]any{"max": s.Max}, Value: headers},
+			"max.files",
+			s.Message.Max,
+		)
+		return FilesValidationError
+	}
+	return nil
+}
+
+func (s Files) assertMaxTotalSize(key string, headers []*multipart.FileHeader, bags *[]FieldError) error {
+	if s.MaxTotalSize <= 0 {
+		return nil
+	}
+	var total int64
+	for _, header := range headers {
+		total += header.Size
+	}
+	if total > s.MaxTotalSize {
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleMaxTotalSize, Params: map[string]any{"max_total_size": s.MaxTotalSize, "total_size": total}, Value: headers},
+			RuleMaxTotalSize,
+			s.Message.MaxTotalSize,
+		)
+		return FilesValidationError
+	}
+	return nil
+}
+
+func (s Files) assertCustomValidation(fc func(v []*multipart.FileHeader, path PathKey, look Lookup) error, jsonSource []byte, value []*multipart.FileHeader, path PathKey, bags *[]FieldError) error {
+	err := fc(value, path, func(k string) gjson.Result {
+		return gjson.GetBytes(jsonSource, k)
+	})
+	if err != nil {
+		fe, messageKey := customFieldError(err, value)
+		appendErrorBags(
+			bags,
+			path.Current,
+			fe,
+			messageKey,
+			s.Message.Custom,
+		)
+		return FilesValidationError
+	}
+	return nil
+}
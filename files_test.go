@@ -0,0 +1,120 @@
+package validet
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func Test_Files(t *testing.T) {
+	png := fileHeader(t, "a.png", "image/png", pngHeader)
+	other := fileHeader(t, "b.png", "image/png", append(append([]byte{}, pngHeader...), 1))
+	copied := fileHeader(t, "c.png", "image/png", pngHeader)
+	script := fileHeader(t, "d.png", "image/png", []byte("#!/bin/sh\necho hi\n"))
+
+	validate := func(rule Files, value any) map[string][]string {
+		schema := NewSchema(DataObject{"attachments": value}, SchemaRules{"attachments": rule}, Options{})
+		bags, _ := schema.Validate()
+		return bags.Errors
+	}
+
+	t.Run("it should accept lists of valid files", func(t *testing.T) {
+		rule := Files{Required: true, Min: 1, Max: 2, Distinct: true, File: File{Mimes: []string{"png"}}}
+		for _, value := range []any{[]*multipart.FileHeader{png, other}, []any{png, other}, png} {
+			if errors := validate(rule, value); len(errors) > 0 {
+				t.Errorf("%T: Actual = %v, Expected no errors", value, errors)
+			}
+		}
+	})
+
+	t.Run("it should check the number of files", func(t *testing.T) {
+		rule := Files{Required: true, Min: 2, Max: 3}
+		cases := []struct {
+			value    any
+			expected map[string][]string
+		}{
+			{nil, map[string][]string{"attachments": {"attachments is required"}}},
+			{[]any{}, map[string][]string{"attachments": {"attachments is required"}}},
+			{[]any{png}, map[string][]string{"attachments": {"attachments must have at least 2 files"}}},
+			{[]any{png, other, copied, script}, map[string][]string{"attachments": {"attachments must have at most 3 files"}}},
+			{[]any{png, "b.png"}, map[string][]string{"attachments": {"attachments must be a list of files"}}},
+			{[]any{png, (*multipart.FileHeader)(nil)}, map[string][]string{"attachments": {"attachments must be a list of files"}}},
+			{[]*multipart.FileHeader{png, nil}, map[string][]string{"attachments": {"attachments must be a list of files"}}},
+		}
+		for _, c := range cases {
+			if errors := validate(rule, c.value); !reflect.DeepEqual(errors, c.expected) {
+				t.Errorf("Actual = %v, Expected = %v", errors, c.expected)
+			}
+		}
+	})
+
+	t.Run("it should reject nil files before reading them", func(t *testing.T) {
+		rule := Files{Distinct: true, MaxTotalSize: png.Size * 4}
+		errors := validate(rule, []*multipart.FileHeader{png, nil, copied})
+		expected := map[string][]string{"attachments": {"attachments must be a list of files"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should check the total size", func(t *testing.T) {
+		rule := Files{MaxTotalSize: png.Size + other.Size - 1}
+		errors := validate(rule, []any{png, other})
+		expected := map[string][]string{"attachments": {"attachments total size must be at maximum 32"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should key the errors of each file by its index", func(t *testing.T) {
+		rule := Files{File: File{Max: png.Size, Mimes: []string{"png"}}}
+		errors := validate(rule, []any{png, other, script})
+		expected := map[string][]string{
+			"attachments.1": {"attachments.1 size must be at maximum 16"},
+			"attachments.2": {"attachments.2 size must be at maximum 16", "attachments.2 must be a file of type png"},
+		}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should detect duplicates by content", func(t *testing.T) {
+		errors := validate(Files{Distinct: true}, []any{png, other, copied})
+		expected := map[string][]string{"attachments.2": {"attachments.2 is a duplicate of attachments.0"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+		if errors := validate(Files{}, []any{png, copied}); len(errors) > 0 {
+			t.Errorf("Actual = %v, Expected no errors without Distinct", errors)
+		}
+	})
+
+	t.Run("it should read the files of multipart requests", func(t *testing.T) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		for _, name := range []string{"a.png", "b.txt"} {
+			part, _ := writer.CreateFormFile("attachments[]", name)
+			part.Write([]byte("plain text " + name))
+		}
+		writer.Close()
+		r := httptest.NewRequest(http.MethodPost, "/", &body)
+		r.Header.Set("Content-Type", writer.FormDataContentType())
+
+		_, err := ParseRequest(httptest.NewRecorder(), r, SchemaRules{
+			"attachments": Files{Required: true, Max: 2, File: File{Mimes: []string{"txt"}}},
+		}, RequestOptions{})
+		if err != nil {
+			t.Errorf("Actual = %v, Expected no error", err)
+		}
+	})
+
+	t.Run("it should reject unknown file types", func(t *testing.T) {
+		_, err := Compile(SchemaRules{"attachments": Files{File: File{Mimes: []string{"nope"}}}})
+		if err == nil || err.Error() != `validet: attachments.*: unknown file type "nope"` {
+			t.Errorf("Actual = %v", err)
+		}
+	})
+}
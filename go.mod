@@ -2,7 +2,11 @@ module github.com/ezartsh/validet
 
 go 1.21.0
 
-require github.com/tidwall/gjson v1.17.0
+require (
+	github.com/tidwall/gjson v1.17.0
+	golang.org/x/text v0.22.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/tidwall/match v1.1.1 // indirect
@@ -0,0 +1,349 @@
+package validet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Media types accepted by ParseRequest.
+const (
+	MediaTypeJSON      = "application/json"
+	MediaTypeForm      = "application/x-www-form-urlencoded"
+	MediaTypeMultipart = "multipart/form-data"
+)
+
+// Defaults of RequestOptions.
+const (
+	DefaultMaxBodySize = 10 << 20
+	DefaultMaxMemory   = 32 << 20
+)
+
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// ErrFormConflict is returned, with a 400 status, for the form and query
+// keys setting the same field twice, e.g. "a" and "a[b]", or a value and a
+// file of the same name.
+var ErrFormConflict = errors.New("conflicting form keys")
+
+// RequestOptions configures ParseRequest and Middleware.
+type RequestOptions struct {
+	// Options are the validation options. Coerce is always enabled for form
+	// bodies and query strings, whose values are all strings; JSON bodies
+	// are only coerced when it is set.
+	Options Options
+	// MaxBodySize limits the size of the body, DefaultMaxBodySize when 0.
+	MaxBodySize int64
+	// MaxMemory is the part of a multipart body kept in memory, the rest of
+	// the files is stored on disk. It defaults to DefaultMaxMemory.
+	MaxMemory int64
+	// MediaTypes lists the accepted media types, by default MediaTypeJSON,
+	// MediaTypeForm and MediaTypeMultipart.
+	MediaTypes []string
+	// ErrorHandler writes the response of a rejected request. It defaults
+	// to WriteRequestError.
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err *RequestError)
+}
+
+// RequestError is the error of a rejected request: Status is the HTTP status
+// to answer with and Errors holds the failed fields when the validation
+// failed (http.StatusUnprocessableEntity).
+type RequestError struct {
+	Status int
+	Errors ErrorBag
+	Err    error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("validet: %s: %v", http.StatusText(e.Status), e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+type requestDataKey struct{}
+
+// Middleware validates the body of the requests, or the query string of GET
+// and HEAD requests, against the schema. Valid requests are passed to next
+// with the validated data in their context, see RequestData; the others are
+// answered by the ErrorHandler.
+func Middleware(schema SchemaRules, options RequestOptions) func(http.Handler) http.Handler {
+	errorHandler := options.ErrorHandler
+	if errorHandler == nil {
+		errorHandler = WriteRequestError
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, err := ParseRequest(w, r, schema, options)
+			if err != nil {
+				var requestErr *RequestError
+				if !errors.As(err, &requestErr) {
+					requestErr = &RequestError{Status: http.StatusBadRequest, Err: err}
+				}
+				errorHandler(w, r, requestErr)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestDataKey{}, data)))
+		})
+	}
+}
+
+// RequestData returns the data validated by Middleware, or nil.
+func RequestData(r *http.Request) DataObject {
+	data, _ := r.Context().Value(requestDataKey{}).(DataObject)
+	return data
+}
+
+// WriteRequestError answers with the status of the error and a JSON body
+// holding a message and, for failed validations, the messages of the
+// failed fields:
+//
+//	{"message": "the given data was invalid", "errors": {"name": ["name is required"]}}
+func WriteRequestError(w http.ResponseWriter, r *http.Request, err *RequestError) {
+	body := DataObject{"message": err.Err.Error()}
+	if err.Status == http.StatusUnprocessableEntity {
+		body = DataObject{"message": "the given data was invalid", "errors": err.Errors.Errors}
+	}
+	w.Header().Set("Content-Type", MediaTypeJSON)
+	w.WriteHeader(err.Status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// ParseRequest reads the body of the request, or the query string of GET
+// and HEAD requests, and validates it against the schema. It returns the
+// validated data, like Bind, or a *RequestError.
+//
+// JSON bodies must hold an object. Form and multipart bodies are converted
+// to a DataObject: repeated keys and keys ending with "[]" become slices,
+// bracketed keys such as "address[city]" or "items[0][title]" become
+// nested objects and slices, and the uploaded files are fed to the File
+// rules as *multipart.FileHeader values. Keys setting the same field twice,
+// such as "a" and "a[b]", or a value and a file of the same name, are
+// rejected with ErrFormConflict.
+//
+// Form bodies and query strings hold strings only, so they are always
+// validated with Options.Coerce. JSON bodies keep the types of their values
+// and are only coerced when RequestOptions.Options asks for it: by default
+// "20" is not accepted by a Numeric rule in a JSON body.
+func ParseRequest(w http.ResponseWriter, r *http.Request, schema SchemaRules, options RequestOptions) (DataObject, error) {
+	validation := Validation{
+		schema:  schema,
+		options: options.Options,
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		data, err := formData(r.URL.Query(), nil)
+		if err != nil {
+			return nil, &RequestError{Status: http.StatusBadRequest, Err: err}
+		}
+		validation.data = data
+		validation.options.Coerce = true
+		return validateRequest(&validation)
+	}
+
+	mediaType, err := requestMediaType(r, options.MediaTypes)
+	if err != nil {
+		return nil, err
+	}
+	maxBodySize := options.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	switch mediaType {
+	case MediaTypeJSON:
+		source, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, requestBodyError(err)
+		}
+		if !validJSONObject(source) {
+			return nil, &RequestError{Status: http.StatusBadRequest, Err: ErrInvalidJSON}
+		}
+		validation.source = source
+	case MediaTypeForm:
+		if err := r.ParseForm(); err != nil {
+			return nil, requestBodyError(err)
+		}
+		data, err := formData(r.PostForm, nil)
+		if err != nil {
+			return nil, &RequestError{Status: http.StatusBadRequest, Err: err}
+		}
+		validation.data = data
+		validation.options.Coerce = true
+	case MediaTypeMultipart:
+		maxMemory := options.MaxMemory
+		if maxMemory <= 0 {
+			maxMemory = DefaultMaxMemory
+		}
+		if err := r.ParseMultipartForm(maxMemory); err != nil {
+			return nil, requestBodyError(err)
+		}
+		data, err := formData(r.MultipartForm.Value, r.MultipartForm.File)
+		if err != nil {
+			return nil, &RequestError{Status: http.StatusBadRequest, Err: err}
+		}
+		validation.data = data
+		validation.options.Coerce = true
+	}
+	return validateRequest(&validation)
+}
+
+func validateRequest(validation *Validation) (DataObject, error) {
+	validated, bags, err := runValidated(validation)
+	if err != nil {
+		return nil, &RequestError{Status: http.StatusUnprocessableEntity, Errors: bags, Err: err}
+	}
+	return validated, nil
+}
+
+func requestMediaType(r *http.Request, accepted []string) (string, error) {
+	if accepted == nil {
+		accepted = []string{MediaTypeJSON, MediaTypeForm, MediaTypeMultipart}
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err == nil {
+		for _, t := range accepted {
+			if mediaType == t {
+				return mediaType, nil
+			}
+		}
+	}
+	return "", &RequestError{
+		Status: http.StatusUnsupportedMediaType,
+		Err:    fmt.Errorf("%w %q, expected %s", ErrUnsupportedMediaType, r.Header.Get("Content-Type"), strings.Join(accepted, ", ")),
+	}
+}
+
+func requestBodyError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &RequestError{Status: http.StatusRequestEntityTooLarge, Err: fmt.Errorf("%w, the limit is %d bytes", ErrBodyTooLarge, maxBytesErr.Limit)}
+	}
+	return &RequestError{Status: http.StatusBadRequest, Err: err}
+}
+
+// formData converts form values and files into a DataObject. The keys are
+// set in order, the values before the files, so that a key setting a field
+// already set by another one is reported the same way every time.
+func formData(values map[string][]string, files map[string][]*multipart.FileHeader) (DataObject, error) {
+	data := DataObject{}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		items := make([]any, len(values[key]))
+		for i, value := range values[key] {
+			items[i] = value
+		}
+		if err := setFormValue(data, key, items); err != nil {
+			return nil, err
+		}
+	}
+	keys = keys[:0]
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		items := make([]any, len(files[key]))
+		for i, header := range files[key] {
+			items[i] = header
+		}
+		if err := setFormValue(data, key, items); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range data {
+		data[key] = formLists(value)
+	}
+	return data, nil
+}
+
+// setFormValue sets the value of a bracketed key, e.g. "items[0][title]".
+// Keys ending with "[]" always hold a slice, the others a single value
+// unless the key is repeated. A key setting a field, or the parent of a
+// field, that another key already set fails with ErrFormConflict.
+func setFormValue(data DataObject, key string, items []any) error {
+	path := formPath(key)
+	var value any = items
+	if last := path[len(path)-1]; last == "" {
+		path = path[:len(path)-1]
+	} else if len(items) == 1 {
+		value = items[0]
+	}
+	if len(path) == 0 {
+		return nil
+	}
+	conflict := fmt.Errorf("%w: %q sets a field already set by another key", ErrFormConflict, key)
+	node := data
+	for _, segment := range path[:len(path)-1] {
+		existing, ok := node[segment]
+		if !ok {
+			child := DataObject{}
+			node[segment] = child
+			node = child
+			continue
+		}
+		child, ok := existing.(DataObject)
+		if !ok {
+			return conflict
+		}
+		node = child
+	}
+	if _, ok := node[path[len(path)-1]]; ok {
+		return conflict
+	}
+	node[path[len(path)-1]] = value
+	return nil
+}
+
+func formPath(key string) []string {
+	name, rest, ok := strings.Cut(key, "[")
+	if !ok || !strings.HasSuffix(rest, "]") {
+		return []string{key}
+	}
+	return append([]string{name}, strings.Split(strings.TrimSuffix(rest, "]"), "][")...)
+}
+
+// formLists turns the objects keyed by the indexes 0 to n-1 into slices.
+func formLists(value any) any {
+	object, ok := value.(DataObject)
+	if !ok {
+		return value
+	}
+	for key, v := range object {
+		object[key] = formLists(v)
+	}
+	if len(object) == 0 {
+		return object
+	}
+	indexes := make([]int, 0, len(object))
+	for key := range object {
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || strconv.Itoa(i) != key {
+			return object
+		}
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	if indexes[len(indexes)-1] != len(indexes)-1 {
+		return object
+	}
+	items := make([]any, len(indexes))
+	for _, i := range indexes {
+		items[i] = object[strconv.Itoa(i)]
+	}
+	return items
+}
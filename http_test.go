@@ -0,0 +1,197 @@
+package validet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Middleware(t *testing.T) {
+	schema := SchemaRules{
+		"name":    String{Required: true, Transform: []Transformer{Trim}},
+		"age":     Numeric[int]{Min: 17},
+		"tags":    Slice[string]{},
+		"address": Object{Item: SchemaObject{"city": String{Required: true}}},
+		"items": SliceObject{Item: SchemaObject{
+			"title": String{Required: true},
+		}},
+	}
+	var received DataObject
+	handler := Middleware(schema, RequestOptions{MaxBodySize: 1024})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = RequestData(r)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		received = nil
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("it should pass the validated JSON data in the context", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name": " Tono ", "tags": ["a"], "address": {"city": "Bogor"}, "extra": true}`))
+		r.Header.Set("Content-Type", "application/json; charset=utf-8")
+		w := serve(r)
+		expected := DataObject{"name": "Tono", "tags": []any{"a"}, "address": DataObject{"city": "Bogor"}}
+		if w.Code != http.StatusNoContent || !reflect.DeepEqual(received, expected) {
+			t.Errorf("Actual = %d %#v, Expected = %#v", w.Code, received, expected)
+		}
+	})
+
+	t.Run("it should convert and coerce form bodies", func(t *testing.T) {
+		form := url.Values{
+			"name":            {"Tono"},
+			"age":             {"20"},
+			"tags[]":          {"a", "b"},
+			"address[city]":   {"Jakarta"},
+			"items[0][title]": {"first"},
+			"items[1][title]": {"second"},
+		}
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := serve(r)
+		expected := DataObject{
+			"name":    "Tono",
+			"age":     20,
+			"tags":    []any{"a", "b"},
+			"address": DataObject{"city": "Jakarta"},
+			"items":   []any{DataObject{"title": "first"}, DataObject{"title": "second"}},
+		}
+		if w.Code != http.StatusNoContent || !reflect.DeepEqual(received, expected) {
+			t.Errorf("Actual = %d %#v, Expected = %#v", w.Code, received, expected)
+		}
+	})
+
+	t.Run("it should validate the query string of GET requests", func(t *testing.T) {
+		w := serve(httptest.NewRequest(http.MethodGet, "/users?name=Tono&age=12&address[city]=Bogor", nil))
+		if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), `"age":["age must be minimum of 17"]`) {
+			t.Errorf("Actual = %d %s", w.Code, w.Body)
+		}
+	})
+
+	t.Run("it should reject the form keys setting the same field twice", func(t *testing.T) {
+		for _, query := range []string{"name=Tono&address=Bogor&address[city]=Bogor", "name=Tono&tags=a&tags[]=b", "name=Tono&items[0]=a&items[0][title]=b"} {
+			for i := 0; i < 5; i++ {
+				w := serve(httptest.NewRequest(http.MethodGet, "/users?"+query, nil))
+				if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "conflicting form keys") {
+					t.Errorf("%s: Actual = %d %s, Expected = %d", query, w.Code, w.Body, http.StatusBadRequest)
+				}
+			}
+		}
+	})
+
+	t.Run("it should only coerce JSON bodies when the options ask for it", func(t *testing.T) {
+		source := `{"name": "Tono", "age": "20", "address": {"city": "Bogor"}}`
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(source))
+		r.Header.Set("Content-Type", "application/json")
+		if w := serve(r); w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Actual = %d %s, Expected = %d", w.Code, w.Body, http.StatusUnprocessableEntity)
+		}
+		r = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(source))
+		r.Header.Set("Content-Type", "application/json")
+		data, err := ParseRequest(httptest.NewRecorder(), r, schema, RequestOptions{Options: Options{Coerce: true}})
+		if err != nil || data["age"] != 20 {
+			t.Errorf("Actual = %#v %v, Expected = %v", data, err, 20)
+		}
+	})
+
+	t.Run("it should answer the failed validations", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"address": {}}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := serve(r)
+		var body struct {
+			Message string              `json:"message"`
+			Errors  map[string][]string `json:"errors"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &body)
+		expected := map[string][]string{"name": {"name is required"}, "address.city": {"city is required"}}
+		if w.Code != http.StatusUnprocessableEntity || received != nil || !reflect.DeepEqual(body.Errors, expected) {
+			t.Errorf("Actual = %d %s", w.Code, w.Body)
+		}
+		if w.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("Actual content type = %s", w.Header().Get("Content-Type"))
+		}
+	})
+
+	t.Run("it should reject unsupported, malformed and large bodies", func(t *testing.T) {
+		tests := []struct {
+			contentType string
+			body        string
+			status      int
+		}{
+			{"text/plain", "name=Tono", http.StatusUnsupportedMediaType},
+			{"", `{"name": "Tono"}`, http.StatusUnsupportedMediaType},
+			{"application/json", `{"name": `, http.StatusBadRequest},
+			{"application/json", `["Tono"]`, http.StatusBadRequest},
+			{"application/json", `{"name": "` + strings.Repeat("a", 2048) + `"}`, http.StatusRequestEntityTooLarge},
+			{"application/x-www-form-urlencoded", "name=" + strings.Repeat("a", 2048), http.StatusRequestEntityTooLarge},
+		}
+		for _, tt := range tests {
+			r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			if w := serve(r); w.Code != tt.status || received != nil {
+				t.Errorf("%s: Actual = %d %s, Expected = %d", tt.contentType, w.Code, w.Body, tt.status)
+			}
+		}
+	})
+
+	t.Run("it should use the configured error handler", func(t *testing.T) {
+		handler := Middleware(schema, RequestOptions{
+			MediaTypes: []string{MediaTypeJSON},
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err *RequestError) {
+				http.Error(w, "rejected", http.StatusTeapot)
+			},
+		})(http.NotFoundHandler())
+		r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader("name=Tono"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusTeapot {
+			t.Errorf("Actual = %d %s", w.Code, w.Body)
+		}
+	})
+}
+
+func Test_ParseRequest_Multipart(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("name", "Tono")
+	part, _ := writer.CreateFormFile("avatar", "avatar.png")
+	part.Write([]byte("\x89PNG\r\n\x1a\n"))
+	writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/users", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	data, err := ParseRequest(httptest.NewRecorder(), r, SchemaRules{
+		"name":   String{Required: true},
+		"avatar": File{Required: true, Max: 1024, Mimes: []string{"png"}},
+	}, RequestOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	avatar, ok := data["avatar"].(*multipart.FileHeader)
+	if !ok || avatar.Filename != "avatar.png" || avatar.Size != 8 || data["name"] != "Tono" {
+		t.Errorf("Actual = %#v", data)
+	}
+
+	body.Reset()
+	writer = multipart.NewWriter(&body)
+	writer.WriteField("avatar", "avatar.png")
+	part, _ = writer.CreateFormFile("avatar", "avatar.png")
+	part.Write([]byte("\x89PNG\r\n\x1a\n"))
+	writer.Close()
+	r = httptest.NewRequest(http.MethodPost, "/users", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	_, err = ParseRequest(httptest.NewRecorder(), r, SchemaRules{"avatar": File{}}, RequestOptions{})
+	var requestErr *RequestError
+	if !errors.As(err, &requestErr) || requestErr.Status != http.StatusBadRequest || !errors.Is(err, ErrFormConflict) {
+		t.Errorf("Actual = %v, Expected = %v", err, ErrFormConflict)
+	}
+}
@@ -0,0 +1,180 @@
+package validet
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"mime/multipart"
+	"strconv"
+	"strings"
+)
+
+// Image restricts the files accepted by File to images. Only the header of
+// the image is decoded, so the check is cheap even for large files. Files
+// that aren't PNG, JPEG or GIF images fail with the "image" rule.
+//
+// Formats lists the allowed formats, "png", "jpeg" (or "jpg") and "gif",
+// every format when empty. Width and Height require exact dimensions and the
+// Min and Max fields limit them, in pixels, when they are set. Ratio is the
+// width divided by the height, e.g. 16.0 / 9, which may differ from it by
+// at most RatioTolerance.
+type Image struct {
+	Formats        []string
+	Width          int
+	Height         int
+	MinWidth       int
+	MaxWidth       int
+	MinHeight      int
+	MaxHeight      int
+	Ratio          float64
+	RatioTolerance float64
+}
+
+var imageFormats = map[string]string{
+	"png":  "png",
+	"jpeg": "jpeg",
+	"jpg":  "jpeg",
+	"gif":  "gif",
+}
+
+// imageFormat resolves an entry of Image.Formats to the name image.Decode
+// reports for the format.
+func imageFormat(entry string) (string, bool) {
+	format, ok := imageFormats[strings.TrimPrefix(strings.ToLower(strings.TrimSpace(entry)), ".")]
+	return format, ok
+}
+
+func (s File) assertImage(key string, value *multipart.FileHeader, bags *[]FieldError, option Options) error {
+	if s.Image == nil {
+		return nil
+	}
+	config, format, err := decodeImageConfig(value)
+	if err != nil {
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleImage, Value: value},
+			RuleImage,
+			s.Message.Image,
+		)
+		return FileValidationError
+	}
+
+	var failed error
+	if err := s.assertImageFormat(key, value, format, bags); err != nil {
+		if option.AbortEarly {
+			return err
+		}
+		failed = err
+	}
+	if err := s.assertDimensions(key, value, config, bags); err != nil {
+		if option.AbortEarly {
+			return err
+		}
+		failed = err
+	}
+	if err := s.assertRatio(key, value, config, bags); err != nil {
+		failed = err
+	}
+	return failed
+}
+
+func (s File) assertImageFormat(key string, value *multipart.FileHeader, format string, bags *[]FieldError) error {
+	if len(s.Image.Formats) == 0 {
+		return nil
+	}
+	for _, entry := range s.Image.Formats {
+		if allowed, ok := imageFormat(entry); ok && allowed == format {
+			return nil
+		}
+	}
+	appendErrorBags(
+		bags,
+		key,
+		FieldError{Rule: RuleImageFormat, Params: map[string]any{"formats": s.Image.Formats, "format": format}, Value: value},
+		RuleImageFormat,
+		s.Message.ImageFormat,
+	)
+	return FileValidationError
+}
+
+// assertDimensions reports every dimension out of its limits.
+func (s File) assertDimensions(key string, value *multipart.FileHeader, config image.Config, bags *[]FieldError) error {
+	limits := []struct {
+		name   string
+		limit  int
+		failed bool
+	}{
+		{"width", s.Image.Width, config.Width != s.Image.Width},
+		{"min_width", s.Image.MinWidth, config.Width < s.Image.MinWidth},
+		{"max_width", s.Image.MaxWidth, config.Width > s.Image.MaxWidth},
+		{"height", s.Image.Height, config.Height != s.Image.Height},
+		{"min_height", s.Image.MinHeight, config.Height < s.Image.MinHeight},
+		{"max_height", s.Image.MaxHeight, config.Height > s.Image.MaxHeight},
+	}
+	var err error
+	for _, l := range limits {
+		if l.limit <= 0 || !l.failed {
+			continue
+		}
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleDimensions, Params: map[string]any{l.name: l.limit, "actual_width": config.Width, "actual_height": config.Height}, Value: value},
+			RuleDimensions+"."+l.name,
+			s.Message.Dimensions,
+		)
+		err = FileValidationError
+	}
+	return err
+}
+
+func (s File) assertRatio(key string, value *multipart.FileHeader, config image.Config, bags *[]FieldError) error {
+	if s.Image.Ratio <= 0 {
+		return nil
+	}
+	if config.Height > 0 && math.Abs(float64(config.Width)/float64(config.Height)-s.Image.Ratio) <= s.Image.RatioTolerance+1e-9 {
+		return nil
+	}
+	appendErrorBags(
+		bags,
+		key,
+		FieldError{Rule: RuleRatio, Params: map[string]any{"ratio": strconv.FormatFloat(s.Image.Ratio, 'g', 4, 64), "actual_width": config.Width, "actual_height": config.Height}, Value: value},
+		RuleRatio,
+		s.Message.Ratio,
+	)
+	return FileValidationError
+}
+
+// decodeImageConfig decodes the dimensions and the format of the image from
+// its header, without reading the pixels.
+func decodeImageConfig(header *multipart.FileHeader) (image.Config, string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return image.Config{}, "", err
+	}
+	defer file.Close()
+	return image.DecodeConfig(file)
+}
+
+func (s Image) compile(path []string) error {
+	for _, entry := range s.Formats {
+		if _, ok := imageFormat(entry); !ok {
+			return builderError(path, fmt.Errorf("unknown image format %q", entry))
+		}
+	}
+	if err := checkBounds("min_width", s.MinWidth, "max_width", s.MaxWidth); err != nil {
+		return builderError(path, err)
+	}
+	if err := checkBounds("min_height", s.MinHeight, "max_height", s.MaxHeight); err != nil {
+		return builderError(path, err)
+	}
+	if s.Width < 0 || s.Height < 0 || s.Ratio < 0 || s.RatioTolerance < 0 {
+		return builderError(path, errors.New("image dimensions and ratio can't be negative"))
+	}
+	return nil
+}
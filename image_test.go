@@ -0,0 +1,107 @@
+package validet
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"reflect"
+	"testing"
+)
+
+func Test_Image(t *testing.T) {
+	encode := func(format string, width, height int) []byte {
+		var buf bytes.Buffer
+		img := image.NewRGBA(image.Rect(0, 0, width, height))
+		switch format {
+		case "png":
+			png.Encode(&buf, img)
+		case "jpeg":
+			jpeg.Encode(&buf, img, nil)
+		case "gif":
+			gif.Encode(&buf, img, nil)
+		}
+		return buf.Bytes()
+	}
+	photo := fileHeader(t, "photo.png", "image/png", encode("png", 160, 90))
+	square := fileHeader(t, "square.jpg", "image/jpeg", encode("jpeg", 50, 50))
+	animation := fileHeader(t, "animation.gif", "image/gif", encode("gif", 20, 10))
+	truncated := fileHeader(t, "broken.png", "image/png", pngHeader)
+
+	validate := func(rule Image, value any) map[string][]string {
+		schema := NewSchema(DataObject{"avatar": value}, SchemaRules{"avatar": File{Image: &rule}}, Options{})
+		bags, _ := schema.Validate()
+		return bags.Errors
+	}
+
+	t.Run("it should accept images within the limits", func(t *testing.T) {
+		rule := Image{Formats: []string{"png", "jpg", "gif"}, MinWidth: 20, MaxWidth: 160, MinHeight: 10, MaxHeight: 90}
+		for _, file := range []any{photo, square, animation} {
+			if errors := validate(rule, file); len(errors) > 0 {
+				t.Errorf("Actual = %v, Expected no errors", errors)
+			}
+		}
+		if errors := validate(Image{Width: 160, Height: 90, Ratio: 16.0 / 9}, photo); len(errors) > 0 {
+			t.Errorf("Actual = %v, Expected no errors", errors)
+		}
+	})
+
+	t.Run("it should reject files that aren't images", func(t *testing.T) {
+		for _, file := range []any{truncated, fileHeader(t, "notes.txt", "text/plain", []byte("notes"))} {
+			errors := validate(Image{}, file)
+			expected := map[string][]string{"avatar": {"avatar must be a PNG, JPEG or GIF image"}}
+			if !reflect.DeepEqual(errors, expected) {
+				t.Errorf("Actual = %v, Expected = %v", errors, expected)
+			}
+		}
+	})
+
+	t.Run("it should check the format", func(t *testing.T) {
+		errors := validate(Image{Formats: []string{"jpeg", "gif"}}, photo)
+		expected := map[string][]string{"avatar": {"avatar must be an image of format jpeg, gif"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should report every dimension out of its limits", func(t *testing.T) {
+		errors := validate(Image{MaxWidth: 100, MinHeight: 100, Width: 150}, photo)
+		expected := map[string][]string{"avatar": {
+			"avatar must be 150 pixels wide",
+			"avatar must be at most 100 pixels wide",
+			"avatar must be at least 100 pixels high",
+		}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+	})
+
+	t.Run("it should check the aspect ratio with its tolerance", func(t *testing.T) {
+		errors := validate(Image{Ratio: 16.0 / 9}, square)
+		expected := map[string][]string{"avatar": {"avatar must have an aspect ratio of 1.778"}}
+		if !reflect.DeepEqual(errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", errors, expected)
+		}
+		if errors := validate(Image{Ratio: 1.5, RatioTolerance: 0.5}, animation); len(errors) > 0 {
+			t.Errorf("Actual = %v, Expected no errors", errors)
+		}
+		if errors := validate(Image{Ratio: 1.5, RatioTolerance: 0.4}, animation); len(errors) == 0 {
+			t.Errorf("Expected the 2:1 image to be out of the tolerance")
+		}
+	})
+
+	t.Run("it should reject invalid image options when compiling", func(t *testing.T) {
+		cases := map[string]Image{
+			`validet: avatar: unknown image format "webp"`:                  {Formats: []string{"webp"}},
+			"validet: avatar: min_width 200 is greater than max_width 100":  {MinWidth: 200, MaxWidth: 100},
+			"validet: avatar: image dimensions and ratio can't be negative": {Ratio: -1},
+		}
+		for expected, rule := range cases {
+			_, err := Compile(SchemaRules{"avatar": File{Image: &rule}})
+			if err == nil || err.Error() != expected {
+				t.Errorf("Actual = %v, Expected = %s", err, expected)
+			}
+		}
+	})
+}
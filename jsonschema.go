@@ -0,0 +1,415 @@
+package validet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDialect is the $schema of the documents produced by JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchemaUnsupported is the keyword listing the rules of a property that
+// JSON Schema can't express, such as Custom funcs or MinDigits. Validators
+// ignore unknown keywords, so the listed rules are only enforced by validet.
+const JSONSchemaUnsupported = "x-validet-unsupported"
+
+// JSONSchema exports the rules as a JSON Schema (draft 2020-12) document,
+// ready to be encoded with encoding/json.
+//
+// Min and Max become minLength/maxLength, minimum/maximum or
+// minItems/maxItems depending on the rule, In and NotIn become enum and
+// not/enum, Regex becomes pattern and Email and Url become format.
+// RequiredIf and RequiredUnless become if/then/else conditions in the
+// root allOf, since their field paths are absolute. Rules that can't be
+// expressed are listed under JSONSchemaUnsupported instead of being dropped.
+func JSONSchema(rules SchemaRules) DataObject {
+	return JSONSchemaOrdered(sortedFields(rules))
+}
+
+// JSONSchemaOrdered is JSONSchema for an OrderedRules schema. The required
+// list follows the declaration order of the fields.
+func JSONSchemaOrdered(rules OrderedRules) DataObject {
+	w := &jsonSchemaWriter{}
+	fields := make([]schemaField, len(rules))
+	for i, field := range rules {
+		fields[i] = schemaField{key: field.Key, value: field.Rule}
+	}
+	document := DataObject{"$schema": JSONSchemaDialect}
+	for k, v := range w.object(nil, fields) {
+		document[k] = v
+	}
+	if len(w.conditions) > 0 {
+		document["allOf"] = w.conditions
+	}
+	return document
+}
+
+// jsonSchemaDescriber is implemented by the rules that can be exported. The
+// returned presenceSpec is applied by the parent object, which owns the
+// required list and the conditions of its properties.
+type jsonSchemaDescriber interface {
+	jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec)
+}
+
+// jsonSchemaWriter collects the conditions of a document while its
+// properties are described. A location is the list of keywords leading to
+// a schema from the root, e.g. ["properties", "items", "items"].
+type jsonSchemaWriter struct {
+	conditions []any
+}
+
+type schemaField struct {
+	key   string
+	value any
+}
+
+// objectFields lists the fields of an Object or SliceObject like itemFields,
+// keeping Item values that are not rules so they can be flagged too.
+func objectFields(fields OrderedRules, item SchemaObject) []schemaField {
+	declared := make(map[string]bool, len(fields))
+	result := make([]schemaField, 0, len(fields)+len(item))
+	for _, field := range fields {
+		declared[field.Key] = true
+		result = append(result, schemaField{key: field.Key, value: field.Rule})
+	}
+	keys := make([]string, 0, len(item))
+	for key := range item {
+		if !declared[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result = append(result, schemaField{key: key, value: item[key]})
+	}
+	return result
+}
+
+func (w *jsonSchemaWriter) object(location []string, fields []schemaField) DataObject {
+	schema := DataObject{"type": "object"}
+	properties := DataObject{}
+	var required []string
+	for _, field := range fields {
+		describer, ok := field.value.(jsonSchemaDescriber)
+		if !ok {
+			properties[field.key] = DataObject{JSONSchemaUnsupported: []string{fmt.Sprintf("%T", field.value)}}
+			continue
+		}
+		property, presence := describer.jsonSchema(w, append(location[:len(location):len(location)], "properties", field.key))
+		if presence.required {
+			required = append(required, field.key)
+		}
+		if presence.requiredIf != nil && !w.condition(location, field.key, presence.requiredIf.FieldPath, presence.requiredIf.Value, false) {
+			unsupported(property, RuleRequiredIf)
+		}
+		if presence.requiredUnless != nil && !w.condition(location, field.key, presence.requiredUnless.FieldPath, presence.requiredUnless.Value, true) {
+			unsupported(property, RuleRequiredUnless)
+		}
+		properties[field.key] = property
+	}
+	if len(properties) > 0 {
+		schema["properties"] = properties
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// condition adds an if/then (or if/else for RequiredUnless) making key of
+// the object at location required depending on the value at path. Only
+// plain dotted paths can be expressed; it reports false otherwise.
+func (w *jsonSchemaWriter) condition(location []string, key string, path string, value any, unless bool) bool {
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" || strings.ContainsAny(segment, `*?#|@\!=<>%[]{}()`) {
+			return false
+		}
+		if _, err := strconv.Atoi(segment); err == nil {
+			return false
+		}
+	}
+
+	test := conditionValue(value)
+	for i := len(segments) - 1; i >= 0; i-- {
+		test = DataObject{
+			"properties": DataObject{segments[i]: test},
+			"required":   []string{segments[i]},
+		}
+	}
+	var required any = DataObject{"required": []string{key}}
+	for i := len(location) - 1; i >= 0; i-- {
+		required = DataObject{location[i]: required}
+	}
+
+	condition := DataObject{"if": test}
+	if unless {
+		condition["else"] = required
+	} else {
+		condition["then"] = required
+	}
+	w.conditions = append(w.conditions, condition)
+	return true
+}
+
+// conditionValue matches the way conditions are evaluated: a string is
+// compared to the string form of the other field, so "true" also matches
+// the boolean true and "10" the number 10, other values to the value of the
+// same JSON type.
+func conditionValue(value any) DataObject {
+	s, ok := value.(string)
+	if !ok {
+		return DataObject{"const": value}
+	}
+	var literal any
+	if err := json.Unmarshal([]byte(s), &literal); err == nil {
+		switch literal.(type) {
+		case bool, float64:
+			return DataObject{"enum": []any{s, literal}}
+		}
+	}
+	return DataObject{"const": s}
+}
+
+func unsupported(schema DataObject, rule string) {
+	rules, _ := schema[JSONSchemaUnsupported].([]string)
+	schema[JSONSchemaUnsupported] = append(rules, rule)
+}
+
+// describeDefault sets the default of a rule. A DefaultFunc can't be
+// expressed.
+func describeDefault(schema DataObject, def any) {
+	switch def.(type) {
+	case nil:
+	case DefaultFunc:
+		unsupported(schema, "default")
+	default:
+		schema["default"] = defaultData(def)
+	}
+}
+
+// allPatterns sets pattern for a single pattern and uses allOf when a value
+// must match several.
+func allPatterns(schema DataObject, patterns []string) {
+	switch len(patterns) {
+	case 0:
+	case 1:
+		schema["pattern"] = patterns[0]
+	default:
+		all := make([]any, len(patterns))
+		for i, pattern := range patterns {
+			all[i] = DataObject{"pattern": pattern}
+		}
+		schema["allOf"] = all
+	}
+}
+
+func (s String) jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec) {
+	schema := DataObject{"type": "string"}
+	if s.Min > 0 {
+		schema["minLength"] = s.Min
+	} else if s.Required {
+		schema["minLength"] = 1
+	}
+	if s.Max > 0 {
+		schema["maxLength"] = s.Max
+	}
+	var patterns []string
+	if s.Regex != "" {
+		patterns = append(patterns, s.Regex)
+	}
+	if s.Alpha {
+		patterns = append(patterns, alphaRegex.String())
+	}
+	if s.AlphaNumeric {
+		patterns = append(patterns, alphaNumericRegex.String())
+	}
+	if s.Url != nil {
+		schema["format"] = "uri"
+		patterns = append(patterns, `^(`+strings.Join(s.Url.schemes(), "|")+`)://`)
+	}
+	if s.Email {
+		schema["format"] = "email"
+	}
+	allPatterns(schema, patterns)
+	var not []any
+	if s.NotRegex != "" {
+		not = append(not, DataObject{"pattern": s.NotRegex})
+	}
+	if len(s.In) > 0 {
+		schema["enum"] = s.In
+	}
+	if len(s.NotIn) > 0 {
+		not = append(not, DataObject{"enum": s.NotIn})
+	}
+	notSchemas(schema, not)
+	if s.Custom != nil {
+		unsupported(schema, RuleCustom)
+	}
+	describeDefault(schema, s.Default)
+	if len(s.Transform) > 0 {
+		unsupported(schema, "transform")
+	}
+	return schema, presenceSpec{s.Required, s.RequiredIf, s.RequiredUnless}
+}
+
+// notSchemas rejects values matching any of the schemas.
+func notSchemas(schema DataObject, not []any) {
+	switch len(not) {
+	case 0:
+	case 1:
+		schema["not"] = not[0]
+	default:
+		schema["not"] = DataObject{"anyOf": not}
+	}
+}
+
+func (s Numeric[NT]) jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec) {
+	schema := DataObject{"type": numericJSONType[NT]()}
+	if s.Min > 0 {
+		schema["minimum"] = s.Min
+	}
+	if s.Max > 0 {
+		schema["maximum"] = s.Max
+	}
+	if len(s.In) > 0 {
+		schema["enum"] = s.In
+	}
+	if len(s.NotIn) > 0 {
+		schema["not"] = DataObject{"enum": s.NotIn}
+	}
+	if s.MinDigits > 0 {
+		unsupported(schema, RuleMinDigits)
+	}
+	if s.MaxDigits > 0 {
+		unsupported(schema, RuleMaxDigits)
+	}
+	if s.Regex != "" {
+		unsupported(schema, RuleRegex)
+	}
+	if s.NotRegex != "" {
+		unsupported(schema, RuleNotRegex)
+	}
+	if s.Custom != nil {
+		unsupported(schema, RuleCustom)
+	}
+	describeDefault(schema, s.Default)
+	return schema, presenceSpec{s.Required, s.RequiredIf, s.RequiredUnless}
+}
+
+func numericJSONType[NT NumericValue]() string {
+	switch any(*new(NT)).(type) {
+	case float32, float64:
+		return "number"
+	}
+	return "integer"
+}
+
+func (s Boolean) jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec) {
+	schema := DataObject{"type": "boolean"}
+	if s.Custom != nil {
+		unsupported(schema, RuleCustom)
+	}
+	describeDefault(schema, s.Default)
+	return schema, presenceSpec{s.Required, s.RequiredIf, s.RequiredUnless}
+}
+
+func (s Slice[T]) jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec) {
+	items := DataObject{"type": "string"}
+	switch any(*new(T)).(type) {
+	case float32, float64:
+		items["type"] = "number"
+	case string:
+	default:
+		items["type"] = "integer"
+	}
+	schema := DataObject{"type": "array", "items": items}
+	arrayLength(schema, s.Required, s.Min, s.Max)
+	if s.Custom != nil {
+		unsupported(schema, RuleCustom)
+	}
+	describeDefault(schema, s.Default)
+	return schema, presenceSpec{s.Required, s.RequiredIf, s.RequiredUnless}
+}
+
+func arrayLength(schema DataObject, required bool, min, max int) {
+	if min > 0 {
+		schema["minItems"] = min
+	} else if required {
+		schema["minItems"] = 1
+	}
+	if max > 0 {
+		schema["maxItems"] = max
+	}
+}
+
+func (s Object) jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec) {
+	schema := w.object(location, objectFields(s.Fields, s.Item))
+	if s.Required {
+		schema["minProperties"] = 1
+	}
+	if s.Custom != nil {
+		unsupported(schema, RuleCustom)
+	}
+	describeDefault(schema, s.Default)
+	return schema, presenceSpec{s.Required, s.RequiredIf, s.RequiredUnless}
+}
+
+func (s SliceObject) jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec) {
+	items := w.object(append(location[:len(location):len(location)], "items"), objectFields(s.Fields, s.Item))
+	schema := DataObject{"type": "array", "items": items}
+	arrayLength(schema, s.Required, s.Min, s.Max)
+	if s.Custom != nil {
+		unsupported(schema, RuleCustom)
+	}
+	describeDefault(schema, s.Default)
+	return schema, presenceSpec{s.Required, s.RequiredIf, s.RequiredUnless}
+}
+
+func (s File) jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec) {
+	schema := DataObject{"type": "string", "format": "binary"}
+	if len(s.Mimes) == 1 {
+		if contentType, ok := fileType(s.Mimes[0]); ok && !strings.HasSuffix(contentType, "/*") {
+			schema["contentMediaType"] = contentType
+		} else {
+			unsupported(schema, RuleMimes)
+		}
+	} else if len(s.Mimes) > 1 {
+		unsupported(schema, RuleMimes)
+	}
+	if s.Min > 0 {
+		unsupported(schema, RuleMin)
+	}
+	if s.Max > 0 {
+		unsupported(schema, RuleMax)
+	}
+	if s.Image != nil {
+		unsupported(schema, RuleImage)
+	}
+	if s.Archive != nil {
+		unsupported(schema, RuleArchive)
+	}
+	if s.Custom != nil {
+		unsupported(schema, RuleCustom)
+	}
+	return schema, presenceSpec{s.Required, s.RequiredIf, s.RequiredUnless}
+}
+
+func (s Files) jsonSchema(w *jsonSchemaWriter, location []string) (DataObject, presenceSpec) {
+	items, _ := s.File.jsonSchema(w, append(location[:len(location):len(location)], "items"))
+	schema := DataObject{"type": "array", "items": items}
+	arrayLength(schema, s.Required, s.Min, s.Max)
+	if s.MaxTotalSize > 0 {
+		unsupported(schema, RuleMaxTotalSize)
+	}
+	if s.Distinct {
+		unsupported(schema, RuleDistinct)
+	}
+	if s.Custom != nil {
+		unsupported(schema, RuleCustom)
+	}
+	return schema, presenceSpec{s.Required, s.RequiredIf, s.RequiredUnless}
+}
@@ -0,0 +1,892 @@
+package validet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupportedKeyword is returned, wrapped with the location of the
+// schema, when a JSON Schema document uses a keyword or a keyword value
+// that has no validet equivalent.
+var ErrUnsupportedKeyword = errors.New("unsupported JSON Schema keyword")
+
+// LoadJSONSchema converts a JSON Schema document into rules. The root schema
+// must describe an object; its properties become the fields.
+//
+// The supported keywords are type, properties, required, items, enum,
+// const, pattern, format, not (with enum or pattern), minLength, maxLength,
+// minItems, maxItems, minimum, maximum, exclusiveMinimum, exclusiveMaximum
+// and multipleOf, plus the if/then/else conditions produced by JSONSchema.
+// Annotations such as title or description are ignored; any other keyword
+// is reported with ErrUnsupportedKeyword, as are the formats other than the
+// email and uri formats of strings, so no constraint is silently dropped.
+//
+// Numbers become Numeric[float64] and arrays of numbers Slice[float64],
+// matching the values decoded from JSON. Integer types and the bounds
+// Numeric can't hold are checked by a Custom func reporting the rule codes
+// RuleInteger, RuleMin, RuleMax, RuleGreaterThan, RuleLessThan and
+// RuleMultipleOf, with their localized messages. As with every validet
+// rule, a required string, array or object must not be empty.
+//
+// $ref is resolved within the document; the keywords next to a $ref apply
+// to the referenced schema, annotations such as description or default
+// replacing its own. File references are only resolved by
+// LoadJSONSchemaFile. Remote and recursive references are not supported.
+func LoadJSONSchema(r io.Reader) (SchemaRules, error) {
+	source, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("validet: load json schema: %w", err)
+	}
+	return newJSONSchemaReader("").load("", source)
+}
+
+// LoadJSONSchemaFile converts the JSON Schema document stored in a file into
+// rules, like LoadJSONSchema. Relative file references are resolved from the
+// directory of the file referencing them, and must stay within the
+// directory of the loaded file.
+func LoadJSONSchemaFile(path string) (SchemaRules, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("validet: load json schema: %w", err)
+	}
+	path = filepath.Clean(path)
+	return newJSONSchemaReader(filepath.Dir(path)).load(path, source)
+}
+
+type jsonSchemaReader struct {
+	root       string
+	documents  map[string]any
+	conditions map[string]*presenceSpec
+	applied    map[string]bool
+	resolving  map[string]bool
+}
+
+func newJSONSchemaReader(root string) *jsonSchemaReader {
+	return &jsonSchemaReader{
+		root:       root,
+		documents:  map[string]any{},
+		conditions: map[string]*presenceSpec{},
+		applied:    map[string]bool{},
+		resolving:  map[string]bool{},
+	}
+}
+
+func (r *jsonSchemaReader) load(file string, source []byte) (SchemaRules, error) {
+	var document any
+	if err := json.Unmarshal(source, &document); err != nil {
+		return nil, fmt.Errorf("validet: load json schema: %w", err)
+	}
+	r.documents[file] = document
+
+	file, schema, _, err := r.resolve(file, document, nil)
+	if err != nil {
+		return nil, err
+	}
+	if t, err := jsonSchemaType(schema, nil); err != nil {
+		return nil, err
+	} else if t != "object" {
+		return nil, jsonSchemaError(nil, fmt.Errorf("root schema must be an object, got %s", t))
+	}
+	if err := checkKeywords(schema, nil, "type", "properties", "required", "additionalProperties", "allOf"); err != nil {
+		return nil, err
+	}
+	if allOf, ok := schema["allOf"]; ok {
+		conditions, ok := allOf.([]any)
+		if !ok {
+			return nil, jsonSchemaError([]string{"allOf"}, errors.New("allOf must be an array"))
+		}
+		for i, condition := range conditions {
+			if err := r.condition(file, condition, []string{"allOf", strconv.Itoa(i)}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	fields, err := r.properties(file, schema, nil)
+	if err != nil {
+		return nil, err
+	}
+	for location := range r.conditions {
+		if !r.applied[location] {
+			return nil, fmt.Errorf("validet: json schema %s: condition on an unknown property", location)
+		}
+	}
+	rules := SchemaRules{}
+	for key, value := range fields {
+		rules[key] = value.(Rule)
+	}
+	return rules, nil
+}
+
+// resolve follows the $ref of a schema until it reaches a schema without
+// one. The returned file is the document the schema was found in and ref
+// identifies the last reference followed, if any. The keywords written next
+// to a $ref are merged into the referenced schema, see mergeRef.
+func (r *jsonSchemaReader) resolve(file string, value any, location []string) (string, map[string]any, string, error) {
+	var ref string
+	var referencing []map[string]any
+	seen := map[string]bool{}
+	for {
+		schema, ok := value.(map[string]any)
+		if !ok {
+			return "", nil, "", jsonSchemaError(location, fmt.Errorf("schema must be an object, got %T", value))
+		}
+		target, ok := schema["$ref"].(string)
+		if !ok {
+			schema, err := mergeRef(schema, referencing, location)
+			if err != nil {
+				return "", nil, "", err
+			}
+			return file, schema, ref, nil
+		}
+		referencing = append(referencing, schema)
+		path, fragment, _ := strings.Cut(target, "#")
+		if strings.Contains(path, "://") {
+			return "", nil, "", jsonSchemaError(location, fmt.Errorf("remote $ref %q is not supported", target))
+		}
+		if path != "" {
+			var err error
+			if file, err = r.file(file, path); err != nil {
+				return "", nil, "", jsonSchemaError(location, fmt.Errorf("$ref %q: %w", target, err))
+			}
+		}
+		ref = file + "#" + fragment
+		if seen[ref] {
+			return "", nil, "", jsonSchemaError(location, fmt.Errorf("circular $ref %q", target))
+		}
+		seen[ref] = true
+		var err error
+		if value, err = jsonPointer(r.documents[file], fragment); err != nil {
+			return "", nil, "", jsonSchemaError(location, fmt.Errorf("$ref %q: %w", target, err))
+		}
+	}
+}
+
+// file loads the document of a file $ref made from the document of file.
+// Only the files of the directory of the loaded schema, or of its
+// subdirectories, can be referenced.
+func (r *jsonSchemaReader) file(file string, path string) (string, error) {
+	if r.root == "" {
+		return "", errors.New("file references are only supported by LoadJSONSchemaFile")
+	}
+	file = filepath.Join(filepath.Dir(file), filepath.FromSlash(path))
+	if rel, err := filepath.Rel(r.root, file); err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%s is outside of %s", file, r.root)
+	}
+	if _, ok := r.documents[file]; ok {
+		return file, nil
+	}
+	source, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	var document any
+	if err := json.Unmarshal(source, &document); err != nil {
+		return "", err
+	}
+	r.documents[file] = document
+	return file, nil
+}
+
+// mergeRef applies the keywords written next to the $ref of the referencing
+// schemas, outermost last, to the referenced schema. Annotations such as
+// description or default replace the ones of the referenced schema; the
+// other keywords are added, as long as they don't conflict with a keyword
+// of the referenced schema.
+func mergeRef(schema map[string]any, referencing []map[string]any, location []string) (map[string]any, error) {
+	if len(referencing) == 0 {
+		return schema, nil
+	}
+	merged := make(map[string]any, len(schema))
+	for keyword, value := range schema {
+		merged[keyword] = value
+	}
+	for i := len(referencing) - 1; i >= 0; i-- {
+		keywords := make([]string, 0, len(referencing[i]))
+		for keyword := range referencing[i] {
+			keywords = append(keywords, keyword)
+		}
+		sort.Strings(keywords)
+		for _, keyword := range keywords {
+			value := referencing[i][keyword]
+			switch {
+			case keyword == "$ref":
+				continue
+			case jsonSchemaAnnotations[keyword] || strings.HasPrefix(keyword, "x-"):
+			default:
+				if current, ok := merged[keyword]; ok && !reflect.DeepEqual(current, value) {
+					return nil, jsonSchemaError(location, fmt.Errorf("%w %q: conflicts with the referenced schema", ErrUnsupportedKeyword, keyword))
+				}
+			}
+			merged[keyword] = value
+		}
+	}
+	return merged, nil
+}
+
+func jsonPointer(document any, fragment string) (any, error) {
+	if fragment == "" {
+		return document, nil
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, errors.New("only JSON pointer fragments are supported")
+	}
+	value := document
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token, err := url.PathUnescape(token)
+		if err != nil {
+			return nil, err
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch v := value.(type) {
+		case map[string]any:
+			value, err = v[token], nil
+			if value == nil {
+				err = fmt.Errorf("%q not found", token)
+			}
+		case []any:
+			i, convErr := strconv.Atoi(token)
+			if convErr != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("%q not found", token)
+			}
+			value = v[i]
+		default:
+			err = fmt.Errorf("%q not found", token)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+func (r *jsonSchemaReader) properties(file string, schema map[string]any, location []string) (SchemaObject, error) {
+	if additional, ok := schema["additionalProperties"]; ok && additional != true {
+		return nil, jsonSchemaError(location, fmt.Errorf("%w %q: only true is supported", ErrUnsupportedKeyword, "additionalProperties"))
+	}
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok && schema["properties"] != nil {
+		return nil, jsonSchemaError(location, errors.New("properties must be an object"))
+	}
+	required := map[string]bool{}
+	if list, ok := schema["required"].([]any); ok {
+		for _, key := range list {
+			name, _ := key.(string)
+			if _, ok := properties[name]; !ok {
+				return nil, jsonSchemaError(location, fmt.Errorf("required property %v has no schema", key))
+			}
+			required[name] = true
+		}
+	} else if schema["required"] != nil {
+		return nil, jsonSchemaError(location, errors.New("required must be an array"))
+	}
+
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	item := SchemaObject{}
+	for _, key := range keys {
+		propertyLocation := append(location[:len(location):len(location)], "properties", key)
+		presence := presenceSpec{required: required[key]}
+		pointer := jsonSchemaPointer(propertyLocation)
+		if condition, ok := r.conditions[pointer]; ok {
+			presence.requiredIf, presence.requiredUnless = condition.requiredIf, condition.requiredUnless
+			r.applied[pointer] = true
+		}
+		rule, err := r.rule(file, properties[key], propertyLocation, presence)
+		if err != nil {
+			return nil, err
+		}
+		item[key] = rule
+	}
+	return item, nil
+}
+
+func (r *jsonSchemaReader) rule(file string, value any, location []string, presence presenceSpec) (Rule, error) {
+	file, schema, ref, err := r.resolve(file, value, location)
+	if err != nil {
+		return nil, err
+	}
+	if ref != "" {
+		if r.resolving[ref] {
+			return nil, jsonSchemaError(location, fmt.Errorf("recursive $ref %q is not supported", ref))
+		}
+		r.resolving[ref] = true
+		defer delete(r.resolving, ref)
+	}
+
+	t, err := jsonSchemaType(schema, location)
+	if err != nil {
+		return nil, err
+	}
+	var rule Rule
+	switch t {
+	case "string":
+		rule, err = jsonSchemaString(schema, location, presence)
+	case "number", "integer":
+		rule, err = jsonSchemaNumber(schema, location, presence, t == "integer")
+	case "boolean":
+		if err := checkKeywords(schema, location, "type"); err != nil {
+			return nil, err
+		}
+		rule = Boolean{Required: presence.required, RequiredIf: presence.requiredIf, RequiredUnless: presence.requiredUnless}
+	case "object":
+		if err := checkKeywords(schema, location, "type", "properties", "required", "additionalProperties", "minProperties"); err != nil {
+			return nil, err
+		}
+		if n, ok := schema["minProperties"].(float64); ok && (n > 1 || n == 1 && !presence.required) {
+			return nil, jsonSchemaError(location, fmt.Errorf("%w %q: only supported on required objects", ErrUnsupportedKeyword, "minProperties"))
+		}
+		item, err := r.properties(file, schema, location)
+		if err != nil {
+			return nil, err
+		}
+		rule = Object{Required: presence.required, RequiredIf: presence.requiredIf, RequiredUnless: presence.requiredUnless, Item: item}
+	case "array":
+		rule, err = r.array(file, schema, location, presence)
+	default:
+		return nil, jsonSchemaError(location, fmt.Errorf("%w %q: type %s is not supported", ErrUnsupportedKeyword, "type", t))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if def, ok := schema["default"]; ok {
+		rule = jsonSchemaDefault(rule, def)
+	}
+	return rule, nil
+}
+
+// jsonSchemaDefault sets the Default of the rules a document is read into.
+// Decoded JSON values already have the types these rules expect.
+func jsonSchemaDefault(rule Rule, def any) Rule {
+	switch r := rule.(type) {
+	case String:
+		r.Default = def
+		return r
+	case Numeric[float64]:
+		r.Default = def
+		return r
+	case Boolean:
+		r.Default = def
+		return r
+	case Slice[string]:
+		r.Default = def
+		return r
+	case Slice[float64]:
+		r.Default = def
+		return r
+	case Object:
+		r.Default = def
+		return r
+	case SliceObject:
+		r.Default = def
+		return r
+	}
+	return rule
+}
+
+func (r *jsonSchemaReader) array(file string, schema map[string]any, location []string, presence presenceSpec) (Rule, error) {
+	if err := checkKeywords(schema, location, "type", "items", "minItems", "maxItems"); err != nil {
+		return nil, err
+	}
+	min, err := jsonSchemaInt(schema, "minItems", location)
+	if err != nil {
+		return nil, err
+	}
+	max, err := jsonSchemaInt(schema, "maxItems", location)
+	if err != nil {
+		return nil, err
+	}
+	if schema["items"] == nil {
+		return nil, jsonSchemaError(location, errors.New("arrays must declare the schema of their items"))
+	}
+	itemsLocation := append(location[:len(location):len(location)], "items")
+	itemsFile, items, _, err := r.resolve(file, schema["items"], itemsLocation)
+	if err != nil {
+		return nil, err
+	}
+	t, err := jsonSchemaType(items, itemsLocation)
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case "object":
+		if err := checkKeywords(items, itemsLocation, "type", "properties", "required", "additionalProperties"); err != nil {
+			return nil, err
+		}
+		item, err := r.properties(itemsFile, items, itemsLocation)
+		if err != nil {
+			return nil, err
+		}
+		return SliceObject{Required: presence.required, RequiredIf: presence.requiredIf, RequiredUnless: presence.requiredUnless, Min: min, Max: max, Item: item}, nil
+	case "string":
+		if err := checkKeywords(items, itemsLocation, "type"); err != nil {
+			return nil, err
+		}
+		return Slice[string]{Required: presence.required, RequiredIf: presence.requiredIf, RequiredUnless: presence.requiredUnless, Min: min, Max: max}, nil
+	case "number", "integer":
+		if err := checkKeywords(items, itemsLocation, "type"); err != nil {
+			return nil, err
+		}
+		rule := Slice[float64]{Required: presence.required, RequiredIf: presence.requiredIf, RequiredUnless: presence.requiredUnless, Min: min, Max: max}
+		if t == "integer" {
+			rule.Custom = integerNumbers
+		}
+		return rule, nil
+	}
+	return nil, jsonSchemaError(itemsLocation, fmt.Errorf("%w %q: arrays of %s are not supported", ErrUnsupportedKeyword, "items", t))
+}
+
+func jsonSchemaString(schema map[string]any, location []string, presence presenceSpec) (Rule, error) {
+	if err := checkKeywords(schema, location, "type", "minLength", "maxLength", "pattern", "enum", "const", "format", "not"); err != nil {
+		return nil, err
+	}
+	rule := String{Required: presence.required, RequiredIf: presence.requiredIf, RequiredUnless: presence.requiredUnless}
+	var err error
+	if rule.Min, err = jsonSchemaInt(schema, "minLength", location); err != nil {
+		return nil, err
+	}
+	if rule.Max, err = jsonSchemaInt(schema, "maxLength", location); err != nil {
+		return nil, err
+	}
+	if rule.Regex, err = jsonSchemaPattern(schema, location); err != nil {
+		return nil, err
+	}
+	if rule.In, err = jsonSchemaStrings(schema, location); err != nil {
+		return nil, err
+	}
+	switch format := schema["format"]; format {
+	case nil:
+	case "email":
+		rule.Email = true
+	case "uri", "url":
+		rule.Url = &Url{Http: true, Https: true}
+	default:
+		return nil, jsonSchemaError(location, fmt.Errorf("%w %q: format %v is not supported", ErrUnsupportedKeyword, "format", format))
+	}
+	if not, ok := schema["not"]; ok {
+		notLocation := append(location[:len(location):len(location)], "not")
+		for _, not := range jsonSchemaNot(not) {
+			if err := checkKeywords(not, notLocation, "enum", "pattern"); err != nil {
+				return nil, err
+			}
+			if _, ok := not["enum"]; ok {
+				values, err := jsonSchemaStrings(not, notLocation)
+				if err != nil {
+					return nil, err
+				}
+				rule.NotIn = append(rule.NotIn, values...)
+			}
+			if _, ok := not["pattern"]; ok {
+				if rule.NotRegex != "" {
+					return nil, jsonSchemaError(notLocation, fmt.Errorf("%w %q: only one pattern is supported", ErrUnsupportedKeyword, "not"))
+				}
+				if rule.NotRegex, err = jsonSchemaPattern(not, notLocation); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return rule, nil
+}
+
+// jsonSchemaNot returns the schemas of a not keyword, which is either a
+// single schema or, as written by JSONSchema, an anyOf of schemas.
+func jsonSchemaNot(value any) []map[string]any {
+	not, _ := value.(map[string]any)
+	anyOf, ok := not["anyOf"].([]any)
+	if !ok || len(not) != 1 {
+		return []map[string]any{not}
+	}
+	schemas := make([]map[string]any, len(anyOf))
+	for i, schema := range anyOf {
+		schemas[i], _ = schema.(map[string]any)
+	}
+	return schemas
+}
+
+func jsonSchemaNumber(schema map[string]any, location []string, presence presenceSpec, integer bool) (Rule, error) {
+	if err := checkKeywords(schema, location, "type", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf", "enum", "const", "not"); err != nil {
+		return nil, err
+	}
+	rule := Numeric[float64]{Required: presence.required, RequiredIf: presence.requiredIf, RequiredUnless: presence.requiredUnless}
+	var checks []func(v float64) *FieldError
+	if integer {
+		checks = append(checks, func(v float64) *FieldError {
+			if v != math.Trunc(v) {
+				return &FieldError{Rule: RuleInteger}
+			}
+			return nil
+		})
+	}
+	for _, keyword := range []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"} {
+		value, ok := schema[keyword]
+		if !ok {
+			continue
+		}
+		n, ok := value.(float64)
+		if !ok {
+			return nil, jsonSchemaError(location, fmt.Errorf("%s must be a number", keyword))
+		}
+		// Numeric holds the positive integer bounds; the other bounds are
+		// checked by the Custom func, reporting the same rule codes.
+		switch {
+		case keyword == "minimum" && n > 0 && n == math.Trunc(n) && n <= math.MaxInt32:
+			rule.Min = int(n)
+		case keyword == "maximum" && n > 0 && n == math.Trunc(n) && n <= math.MaxInt32:
+			rule.Max = int(n)
+		case keyword == "minimum":
+			checks = append(checks, func(v float64) *FieldError {
+				if v < n {
+					return &FieldError{Rule: RuleMin, Params: map[string]any{"min": n}, messageKey: "min.numeric"}
+				}
+				return nil
+			})
+		case keyword == "maximum":
+			checks = append(checks, func(v float64) *FieldError {
+				if v > n {
+					return &FieldError{Rule: RuleMax, Params: map[string]any{"max": n}, messageKey: "max.numeric"}
+				}
+				return nil
+			})
+		case keyword == "exclusiveMinimum":
+			checks = append(checks, func(v float64) *FieldError {
+				if v <= n {
+					return &FieldError{Rule: RuleGreaterThan, Params: map[string]any{"gt": n}}
+				}
+				return nil
+			})
+		case keyword == "exclusiveMaximum":
+			checks = append(checks, func(v float64) *FieldError {
+				if v >= n {
+					return &FieldError{Rule: RuleLessThan, Params: map[string]any{"lt": n}}
+				}
+				return nil
+			})
+		case keyword == "multipleOf":
+			if n <= 0 {
+				return nil, jsonSchemaError(location, errors.New("multipleOf must be greater than 0"))
+			}
+			checks = append(checks, func(v float64) *FieldError {
+				if q := v / n; math.Abs(q-math.Round(q)) > 1e-9 {
+					return &FieldError{Rule: RuleMultipleOf, Params: map[string]any{"multiple_of": n}}
+				}
+				return nil
+			})
+		}
+	}
+	if len(checks) > 0 {
+		rule.Custom = func(v float64, _ PathKey, _ Lookup) error {
+			for _, check := range checks {
+				if fe := check(v); fe != nil {
+					return *fe
+				}
+			}
+			return nil
+		}
+	}
+
+	var err error
+	if rule.In, err = jsonSchemaNumbers(schema, location); err != nil {
+		return nil, err
+	}
+	if not, ok := schema["not"]; ok {
+		notLocation := append(location[:len(location):len(location)], "not")
+		notSchema, _ := not.(map[string]any)
+		if err := checkKeywords(notSchema, notLocation, "enum"); err != nil {
+			return nil, err
+		}
+		if rule.NotIn, err = jsonSchemaNumbers(notSchema, notLocation); err != nil {
+			return nil, err
+		}
+	}
+	return rule, nil
+}
+
+func jsonSchemaType(schema map[string]any, location []string) (string, error) {
+	switch t := schema["type"].(type) {
+	case string:
+		return t, nil
+	case []any:
+		// Null values are treated as absent by every rule, so a nullable
+		// type is the type itself.
+		var types []string
+		for _, value := range t {
+			if value != "null" {
+				types = append(types, fmt.Sprint(value))
+			}
+		}
+		if len(types) == 1 {
+			return types[0], nil
+		}
+		return "", jsonSchemaError(location, fmt.Errorf("%w %q: only a single type is supported", ErrUnsupportedKeyword, "type"))
+	case nil:
+		if _, ok := schema["properties"]; ok {
+			return "object", nil
+		}
+		if _, ok := schema["items"]; ok {
+			return "array", nil
+		}
+		return "", jsonSchemaError(location, errors.New("schema must declare a type"))
+	}
+	return "", jsonSchemaError(location, errors.New("type must be a string or an array"))
+}
+
+func jsonSchemaInt(schema map[string]any, keyword string, location []string) (int, error) {
+	value, ok := schema[keyword]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) || n > math.MaxInt32 {
+		return 0, jsonSchemaError(location, fmt.Errorf("%s must be a non-negative integer", keyword))
+	}
+	return int(n), nil
+}
+
+func jsonSchemaPattern(schema map[string]any, location []string) (string, error) {
+	value, ok := schema["pattern"]
+	if !ok {
+		return "", nil
+	}
+	pattern, ok := value.(string)
+	if !ok {
+		return "", jsonSchemaError(location, errors.New("pattern must be a string"))
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", jsonSchemaError(location, fmt.Errorf("invalid pattern %q: %w", pattern, err))
+	}
+	return pattern, nil
+}
+
+// jsonSchemaValues returns the values allowed by enum or const.
+func jsonSchemaValues(schema map[string]any, location []string) ([]any, error) {
+	if value, ok := schema["const"]; ok {
+		return []any{value}, nil
+	}
+	value, ok := schema["enum"]
+	if !ok {
+		return nil, nil
+	}
+	values, ok := value.([]any)
+	if !ok {
+		return nil, jsonSchemaError(location, errors.New("enum must be an array"))
+	}
+	return values, nil
+}
+
+func jsonSchemaStrings(schema map[string]any, location []string) ([]string, error) {
+	values, err := jsonSchemaValues(schema, location)
+	if err != nil {
+		return nil, err
+	}
+	var strs []string
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, jsonSchemaError(location, fmt.Errorf("enum value %v is not a string", value))
+		}
+		strs = append(strs, s)
+	}
+	return strs, nil
+}
+
+func jsonSchemaNumbers(schema map[string]any, location []string) ([]float64, error) {
+	values, err := jsonSchemaValues(schema, location)
+	if err != nil {
+		return nil, err
+	}
+	var numbers []float64
+	for _, value := range values {
+		n, ok := value.(float64)
+		if !ok {
+			return nil, jsonSchemaError(location, fmt.Errorf("enum value %v is not a number", value))
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+// condition reads an if/then or if/else condition written by JSONSchema and
+// records it for the property it makes required.
+func (r *jsonSchemaReader) condition(file string, value any, location []string) error {
+	_, schema, _, err := r.resolve(file, value, location)
+	if err != nil {
+		return err
+	}
+	if err := checkKeywords(schema, location, "if", "then", "else"); err != nil {
+		return err
+	}
+	_, hasThen := schema["then"]
+	_, hasElse := schema["else"]
+	if hasThen == hasElse || schema["if"] == nil {
+		return jsonSchemaError(location, fmt.Errorf("%w %q: only if/then or if/else conditions are supported", ErrUnsupportedKeyword, "allOf"))
+	}
+	path, expected, err := conditionTest(schema["if"], append(location[:len(location):len(location)], "if"))
+	if err != nil {
+		return err
+	}
+	result, keyword := schema["then"], "then"
+	if hasElse {
+		result, keyword = schema["else"], "else"
+	}
+	target, keys, err := conditionTarget(result, append(location[:len(location):len(location)], keyword))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		pointer := jsonSchemaPointer(append(target[:len(target):len(target)], "properties", key))
+		presence, ok := r.conditions[pointer]
+		if !ok {
+			presence = &presenceSpec{}
+			r.conditions[pointer] = presence
+		}
+		if hasElse && presence.requiredUnless == nil {
+			presence.requiredUnless = &RequiredUnless{FieldPath: path, Value: expected}
+		} else if hasThen && presence.requiredIf == nil {
+			presence.requiredIf = &RequiredIf{FieldPath: path, Value: expected}
+		} else {
+			return jsonSchemaError(location, fmt.Errorf("%w %q: %s has more than one condition of the same kind", ErrUnsupportedKeyword, "allOf", pointer))
+		}
+	}
+	return nil
+}
+
+// conditionTest reads the field path and the value of an if schema made of
+// nested single properties ending with const or enum.
+func conditionTest(value any, location []string) (string, string, error) {
+	var segments []string
+	unsupported := jsonSchemaError(location, fmt.Errorf("%w %q: only a const or enum on a single property is supported", ErrUnsupportedKeyword, "if"))
+	for {
+		schema, ok := value.(map[string]any)
+		if !ok {
+			return "", "", unsupported
+		}
+		if properties, ok := schema["properties"].(map[string]any); ok {
+			if len(properties) != 1 || checkKeywords(schema, location, "properties", "required") != nil {
+				return "", "", unsupported
+			}
+			for key, property := range properties {
+				segments = append(segments, key)
+				value = property
+			}
+			continue
+		}
+		if len(segments) == 0 || checkKeywords(schema, location, "const", "enum") != nil {
+			return "", "", unsupported
+		}
+		values, err := jsonSchemaValues(schema, location)
+		if err != nil || len(values) == 0 {
+			return "", "", unsupported
+		}
+		// The other field is compared by its string form, so values with
+		// different string forms can't be expressed.
+		expected := conditionString(values[0])
+		for _, v := range values[1:] {
+			if conditionString(v) != expected {
+				return "", "", unsupported
+			}
+		}
+		return strings.Join(segments, "."), expected, nil
+	}
+}
+
+func conditionString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// conditionTarget reads the location of the object and the keys a then or
+// else schema makes required.
+func conditionTarget(value any, location []string) ([]string, []string, error) {
+	var target []string
+	unsupported := jsonSchemaError(location, fmt.Errorf("%w %q: only required properties are supported", ErrUnsupportedKeyword, location[len(location)-1]))
+	for {
+		schema, ok := value.(map[string]any)
+		if !ok || len(schema) != 1 {
+			return nil, nil, unsupported
+		}
+		if properties, ok := schema["properties"].(map[string]any); ok && len(properties) == 1 {
+			for key, property := range properties {
+				target = append(target, "properties", key)
+				value = property
+			}
+			continue
+		}
+		if items, ok := schema["items"]; ok {
+			target = append(target, "items")
+			value = items
+			continue
+		}
+		list, ok := schema["required"].([]any)
+		if !ok || len(list) == 0 {
+			return nil, nil, unsupported
+		}
+		keys := make([]string, len(list))
+		for i, key := range list {
+			if keys[i], ok = key.(string); !ok {
+				return nil, nil, unsupported
+			}
+		}
+		return target, keys, nil
+	}
+}
+
+var jsonSchemaAnnotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "$defs": true, "definitions": true, "$anchor": true,
+	"title": true, "description": true, "default": true, "examples": true, "deprecated": true,
+	"readOnly": true, "writeOnly": true,
+}
+
+// checkKeywords reports the first keyword of the schema that is neither an
+// annotation, an extension (x-*) nor one of the allowed keywords.
+func checkKeywords(schema map[string]any, location []string, allowed ...string) error {
+	keywords := make([]string, 0, len(schema))
+	for keyword := range schema {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+	for _, keyword := range keywords {
+		if jsonSchemaAnnotations[keyword] || strings.HasPrefix(keyword, "x-") {
+			continue
+		}
+		known := false
+		for _, a := range allowed {
+			known = known || a == keyword
+		}
+		if !known {
+			return jsonSchemaError(location, fmt.Errorf("%w %q", ErrUnsupportedKeyword, keyword))
+		}
+	}
+	return nil
+}
+
+func jsonSchemaPointer(location []string) string {
+	tokens := make([]string, len(location))
+	for i, token := range location {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+	}
+	return "#/" + strings.Join(tokens, "/")
+}
+
+func jsonSchemaError(location []string, err error) error {
+	return fmt.Errorf("validet: json schema %s: %w", jsonSchemaPointer(location), err)
+}
@@ -0,0 +1,250 @@
+package validet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_LoadJSONSchema(t *testing.T) {
+	t.Run("it should convert the supported keywords into rules", func(t *testing.T) {
+		rules, err := LoadJSONSchema(strings.NewReader(`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"title": "User",
+			"type": "object",
+			"required": ["name", "tags"],
+			"properties": {
+				"name": {"type": "string", "minLength": 3, "maxLength": 50, "pattern": "^[a-z]+$"},
+				"email": {"type": "string", "format": "email", "not": {"enum": ["root@mail.com"]}},
+				"website": {"type": "string", "format": "uri"},
+				"role": {"type": "string", "enum": ["admin", "member"]},
+				"active": {"type": "boolean"},
+				"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 5},
+				"address": {"$ref": "#/$defs/address"},
+				"items": {
+					"type": "array",
+					"minItems": 1,
+					"items": {"type": "object", "required": ["title"], "properties": {"title": {"type": "string"}}}
+				}
+			},
+			"$defs": {
+				"address": {"type": "object", "properties": {"city": {"type": ["string", "null"]}}}
+			}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := SchemaRules{
+			"name":    String{Required: true, Min: 3, Max: 50, Regex: "^[a-z]+$"},
+			"email":   String{Email: true, NotIn: []string{"root@mail.com"}},
+			"website": String{Url: &Url{Http: true, Https: true}},
+			"role":    String{In: []string{"admin", "member"}},
+			"active":  Boolean{},
+			"tags":    Slice[string]{Required: true, Max: 5},
+			"address": Object{Item: SchemaObject{"city": String{}}},
+			"items":   SliceObject{Min: 1, Item: SchemaObject{"title": String{Required: true}}},
+		}
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rules, expected)
+		}
+	})
+
+	t.Run("it should check numbers and integers", func(t *testing.T) {
+		rules, err := LoadJSONSchema(strings.NewReader(`{
+			"type": "object",
+			"properties": {
+				"age": {"type": "integer", "minimum": 17, "maximum": 99},
+				"ratio": {"type": "number", "minimum": 0, "exclusiveMaximum": 1}
+			}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests := []struct {
+			data   string
+			errors map[string][]string
+		}{
+			{`{"age": 20, "ratio": 0.5}`, nil},
+			{`{"age": 20.5, "ratio": -1}`, map[string][]string{"age": {"age must be an integer"}, "ratio": {"ratio must be minimum of 0"}}},
+			{`{"age": 10, "ratio": 1}`, map[string][]string{"age": {"age must be minimum of 17"}, "ratio": {"ratio must be less than 1"}}},
+		}
+		for _, tt := range tests {
+			bags, _ := ValidateJSON([]byte(tt.data), rules, Options{})
+			if len(bags.Errors) != len(tt.errors) || (tt.errors != nil && !reflect.DeepEqual(bags.Errors, tt.errors)) {
+				t.Errorf("%s: Actual = %v, Expected = %v", tt.data, bags.Errors, tt.errors)
+			}
+		}
+	})
+
+	t.Run("it should report the numeric bounds with their rule codes", func(t *testing.T) {
+		rules, err := LoadJSONSchema(strings.NewReader(`{
+			"type": "object",
+			"properties": {
+				"step": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.5},
+				"scores": {"type": "array", "items": {"type": "integer"}}
+			}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tests := []struct {
+			data   string
+			locale string
+			rules  map[string]string
+			errors map[string][]string
+		}{
+			{`{"step": 0, "scores": [1, 2.5]}`, "", map[string]string{"step": RuleGreaterThan, "scores": RuleInteger}, map[string][]string{"step": {"step must be greater than 0"}, "scores": {"scores must only contain integers"}}},
+			{`{"step": 0.7}`, LocaleIndonesian, map[string]string{"step": RuleMultipleOf}, map[string][]string{"step": {"step harus kelipatan 0.5"}}},
+		}
+		for _, tt := range tests {
+			bags, _ := ValidateJSON([]byte(tt.data), rules, Options{Locale: tt.locale})
+			if !reflect.DeepEqual(bags.Errors, tt.errors) {
+				t.Errorf("%s: Actual = %v, Expected = %v", tt.data, bags.Errors, tt.errors)
+			}
+			for key, rule := range tt.rules {
+				if actual := bags.Fields[key][0].Rule; actual != rule {
+					t.Errorf("%s: Actual = %v, Expected = %v", key, actual, rule)
+				}
+			}
+		}
+	})
+
+	t.Run("it should merge the keywords written next to a $ref", func(t *testing.T) {
+		rules, err := LoadJSONSchema(strings.NewReader(`{
+			"type": "object",
+			"properties": {
+				"nick": {"$ref": "#/$defs/name", "description": "Nickname", "default": "anon", "maxLength": 10},
+				"name": {"$ref": "#/$defs/name", "minLength": 3}
+			},
+			"$defs": {"name": {"type": "string", "minLength": 3, "default": "none"}}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := SchemaRules{
+			"nick": String{Min: 3, Max: 10, Default: "anon"},
+			"name": String{Min: 3, Default: "none"},
+		}
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rules, expected)
+		}
+
+		_, err = LoadJSONSchema(strings.NewReader(`{
+			"type": "object",
+			"properties": {"name": {"$ref": "#/$defs/name", "minLength": 5}},
+			"$defs": {"name": {"type": "string", "minLength": 3}}
+		}`))
+		if !errors.Is(err, ErrUnsupportedKeyword) || !strings.Contains(err.Error(), `"minLength": conflicts with the referenced schema`) {
+			t.Errorf("Actual = %v, Expected = %v", err, "a minLength conflict")
+		}
+	})
+
+	t.Run("it should only read the files of the directory of the schema", func(t *testing.T) {
+		dir := t.TempDir()
+		os.MkdirAll(filepath.Join(dir, "schemas"), 0o755)
+		os.WriteFile(filepath.Join(dir, "secret.json"), []byte(`{"type": "string"}`), 0o644)
+		os.WriteFile(filepath.Join(dir, "schemas", "user.json"), []byte(`{
+			"type": "object",
+			"properties": {"token": {"$ref": "../secret.json"}}
+		}`), 0o644)
+
+		_, err := LoadJSONSchemaFile(filepath.Join(dir, "schemas", "user.json"))
+		if err == nil || !strings.Contains(err.Error(), "is outside of") {
+			t.Errorf("Actual = %v, Expected = %v", err, "an outside of the directory error")
+		}
+		_, err = LoadJSONSchema(strings.NewReader(`{"type": "object", "properties": {"token": {"$ref": "secret.json"}}}`))
+		if err == nil || !strings.Contains(err.Error(), "only supported by LoadJSONSchemaFile") {
+			t.Errorf("Actual = %v, Expected = %v", err, "a file reference error")
+		}
+	})
+
+	t.Run("it should load the schemas referenced from local files", func(t *testing.T) {
+		dir := t.TempDir()
+		os.MkdirAll(filepath.Join(dir, "common"), 0o755)
+		os.WriteFile(filepath.Join(dir, "user.json"), []byte(`{
+			"type": "object",
+			"required": ["address"],
+			"properties": {"address": {"$ref": "common/address.json"}}
+		}`), 0o644)
+		os.WriteFile(filepath.Join(dir, "common", "address.json"), []byte(`{
+			"type": "object",
+			"properties": {"zip": {"$ref": "types.json#/$defs/zip"}}
+		}`), 0o644)
+		os.WriteFile(filepath.Join(dir, "common", "types.json"), []byte(`{
+			"$defs": {"zip": {"type": "string", "pattern": "^[0-9]{5}$"}}
+		}`), 0o644)
+
+		rules, err := LoadJSONSchemaFile(filepath.Join(dir, "user.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := SchemaRules{
+			"address": Object{Required: true, Item: SchemaObject{"zip": String{Regex: "^[0-9]{5}$"}}},
+		}
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rules, expected)
+		}
+	})
+
+	t.Run("it should read back the conditions written by JSONSchema", func(t *testing.T) {
+		source := JSONSchema(SchemaRules{
+			"type": String{},
+			"company": Object{Item: SchemaObject{
+				"name": String{RequiredIf: &RequiredIf{FieldPath: "type", Value: "company"}},
+			}},
+			"nik": String{RequiredUnless: &RequiredUnless{FieldPath: "info.verified", Value: "true"}},
+		})
+		document, err := json.Marshal(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules, err := LoadJSONSchema(bytes.NewReader(document))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := SchemaRules{
+			"type": String{},
+			"company": Object{Item: SchemaObject{
+				"name": String{RequiredIf: &RequiredIf{FieldPath: "type", Value: "company"}},
+			}},
+			"nik": String{RequiredUnless: &RequiredUnless{FieldPath: "info.verified", Value: "true"}},
+		}
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rules, expected)
+		}
+	})
+
+	t.Run("it should report unsupported schemas", func(t *testing.T) {
+		tests := []struct {
+			schema      string
+			unsupported bool
+			message     string
+		}{
+			{`{"type": "object", "properties": {"a": {"type": "string", "oneOf": []}}}`, true, `#/properties/a: unsupported JSON Schema keyword "oneOf"`},
+			{`{"type": "object", "additionalProperties": false}`, true, `additionalProperties`},
+			{`{"type": "object", "properties": {"a": {"type": "string", "format": "uuid"}}}`, true, `#/properties/a: unsupported JSON Schema keyword "format": format uuid is not supported`},
+			{`{"type": "object", "properties": {"a": {"type": "string", "format": "date-time"}}}`, true, `format date-time`},
+			{`{"type": "object", "properties": {"a": {"type": "integer", "format": "int64"}}}`, true, `"format"`},
+			{`{"type": "object", "properties": {"a": {"type": ["string", "number"]}}}`, true, `#/properties/a`},
+			{`{"type": "object", "properties": {"a": {"$ref": "https://example.com/a.json"}}}`, false, `remote $ref`},
+			{`{"type": "object", "properties": {"a": {"$ref": "#/$defs/a"}}, "$defs": {"a": {"type": "object", "properties": {"b": {"$ref": "#/$defs/a"}}}}}`, false, `recursive $ref`},
+			{`{"type": "object", "properties": {"a": {"$ref": "missing.json"}}}`, false, `missing.json`},
+			{`{"type": "array"}`, false, `root schema must be an object`},
+		}
+		for _, tt := range tests {
+			_, err := LoadJSONSchema(strings.NewReader(tt.schema))
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.schema)
+				continue
+			}
+			if errors.Is(err, ErrUnsupportedKeyword) != tt.unsupported || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("%s: unexpected error %v", tt.schema, err)
+			}
+		}
+	})
+}
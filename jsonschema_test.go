@@ -0,0 +1,137 @@
+package validet
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func assertJSONEqual(t *testing.T, actual any, expected string) {
+	t.Helper()
+	actualJSON, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var a, e any
+	if err := json.Unmarshal(actualJSON, &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		t.Fatal(err)
+	}
+	aJSON, _ := json.Marshal(a)
+	eJSON, _ := json.Marshal(e)
+	if string(aJSON) != string(eJSON) {
+		t.Errorf("Actual = %s, Expected = %s", aJSON, eJSON)
+	}
+}
+
+func Test_JSONSchema(t *testing.T) {
+	t.Run("it should export the rules of every type", func(t *testing.T) {
+		schema := JSONSchemaOrdered(OrderedRules{
+			{"name", String{Required: true, Min: 3, Max: 50, Alpha: true}},
+			{"email", String{Email: true, NotIn: []string{"root@mail.com"}}},
+			{"role", String{In: []string{"admin", "member"}}},
+			{"site", String{Url: &Url{Https: true}}},
+			{"age", Numeric[int]{Required: true, Min: 17, Max: 99}},
+			{"score", Numeric[float64]{NotIn: []float64{0.5}}},
+			{"active", Boolean{}},
+			{"tags", Slice[string]{Required: true, Max: 5}},
+			{"address", Object{Item: SchemaObject{
+				"city":   String{Required: true},
+				"avatar": File{Mimes: []string{"image/png"}},
+			}}},
+			{"items", SliceObject{Min: 1, Fields: OrderedRules{
+				{"title", String{Required: true}},
+			}}},
+		})
+		assertJSONEqual(t, schema, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"required": ["name", "age", "tags"],
+			"properties": {
+				"name": {"type": "string", "minLength": 3, "maxLength": 50, "pattern": "^[a-zA-Z]+$"},
+				"email": {"type": "string", "format": "email", "not": {"enum": ["root@mail.com"]}},
+				"role": {"type": "string", "enum": ["admin", "member"]},
+				"site": {"type": "string", "format": "uri", "pattern": "^(https)://"},
+				"age": {"type": "integer", "minimum": 17, "maximum": 99},
+				"score": {"type": "number", "not": {"enum": [0.5]}},
+				"active": {"type": "boolean"},
+				"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 5},
+				"address": {
+					"type": "object",
+					"required": ["city"],
+					"properties": {
+						"avatar": {"type": "string", "format": "binary", "contentMediaType": "image/png"},
+						"city": {"type": "string", "minLength": 1}
+					}
+				},
+				"items": {
+					"type": "array",
+					"minItems": 1,
+					"items": {
+						"type": "object",
+						"required": ["title"],
+						"properties": {"title": {"type": "string", "minLength": 1}}
+					}
+				}
+			}
+		}`)
+	})
+
+	t.Run("it should export conditional requirements as if/then", func(t *testing.T) {
+		schema := JSONSchema(SchemaRules{
+			"type": String{In: []string{"person", "company"}},
+			"company": Object{Item: SchemaObject{
+				"name": String{RequiredIf: &RequiredIf{FieldPath: "type", Value: "company"}},
+			}},
+			"nik": String{RequiredUnless: &RequiredUnless{FieldPath: "info.verified", Value: "true"}},
+		})
+		assertJSONEqual(t, schema["allOf"], `[
+			{
+				"if": {"properties": {"type": {"const": "company"}}, "required": ["type"]},
+				"then": {"properties": {"company": {"required": ["name"]}}}
+			},
+			{
+				"if": {
+					"properties": {"info": {"properties": {"verified": {"enum": ["true", true]}}, "required": ["verified"]}},
+					"required": ["info"]
+				},
+				"else": {"required": ["nik"]}
+			}
+		]`)
+	})
+
+	t.Run("it should flag the rules it can't express", func(t *testing.T) {
+		schema := JSONSchema(SchemaRules{
+			"code":  String{Custom: func(v string, path PathKey, look Lookup) error { return errors.New("invalid") }},
+			"pin":   Numeric[int]{MinDigits: 4, Regex: `^\d+$`},
+			"ref":   String{RequiredIf: &RequiredIf{FieldPath: "items.#.type", Value: "a"}},
+			"point": opaqueRule{},
+		})
+		assertJSONEqual(t, schema["properties"], `{
+			"code": {"type": "string", "x-validet-unsupported": ["custom"]},
+			"pin": {"type": "integer", "x-validet-unsupported": ["min_digits", "regex"]},
+			"ref": {"type": "string", "x-validet-unsupported": ["required_if"]},
+			"point": {"x-validet-unsupported": ["validet.opaqueRule"]}
+		}`)
+		if _, ok := schema["allOf"]; ok {
+			t.Errorf("expected no conditions, got %v", schema["allOf"])
+		}
+	})
+}
+
+type opaqueRule struct{}
+
+func (r opaqueRule) Validate(source []byte, value any, ctx RuleContext) ([]FieldError, error) {
+	return nil, nil
+}
+
+func (r opaqueRule) IsMyTypeOf(schema any) bool {
+	_, ok := schema.(opaqueRule)
+	return ok
+}
+
+func (r opaqueRule) Process(ctx RuleContext) ([]FieldError, error) {
+	return nil, nil
+}
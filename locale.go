@@ -0,0 +1,195 @@
+package validet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	LocaleEnglish    = "en"
+	LocaleIndonesian = "id"
+)
+
+// DefaultLocale returns the locale used when Options.Locale is empty and as
+// the fallback for keys missing from the selected locale, LocaleEnglish
+// unless changed by SetDefaultLocale.
+func DefaultLocale() string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	return catalog.locale
+}
+
+// SetDefaultLocale changes the locale returned by DefaultLocale. It is safe
+// to call while other goroutines validate.
+func SetDefaultLocale(locale string) {
+	catalog.Lock()
+	defer catalog.Unlock()
+	catalog.locale = normalizeLocale(locale)
+}
+
+var indonesianMessages = Messages{
+	RuleRequired:            "{field} wajib diisi",
+	RuleRequiredIf:          "{field} wajib diisi",
+	RuleRequiredUnless:      "{field} wajib diisi",
+	"type.string":           "{field} harus berupa teks",
+	"type.numeric":          "{field} harus berupa angka bertipe {type}",
+	"type.coerce":           "{field} tidak dapat diubah menjadi {type} tanpa kehilangan data",
+	"type.boolean":          "{field} harus berupa boolean",
+	"type.object":           "{field} harus berupa objek",
+	"type.slice":            "{field} harus berupa daftar bertipe {type}",
+	"type.slice_object":     "{field} harus berupa daftar objek",
+	"type.file":             "{field} harus berupa berkas",
+	"type.files":            "{field} harus berupa daftar berkas",
+	"min.string":            "{field} minimal {min} karakter",
+	"max.string":            "{field} maksimal {max} karakter",
+	"min.numeric":           "{field} minimal bernilai {min}",
+	"max.numeric":           "{field} maksimal bernilai {max}",
+	"min.slice":             "{field} minimal berisi {min} item",
+	"max.slice":             "{field} maksimal berisi {max} item",
+	"min.slice_object":      "{field} minimal berisi {min} item",
+	"max.slice_object":      "{field} maksimal berisi {max} item",
+	"min.file":              "Ukuran {field} minimal {min} byte",
+	"max.file":              "Ukuran {field} maksimal {max} byte",
+	RuleMimes:               "{field} harus berupa berkas bertipe {mimes}",
+	RuleImage:               "{field} harus berupa gambar PNG, JPEG atau GIF",
+	RuleImageFormat:         "{field} harus berupa gambar berformat {formats}",
+	"dimensions.width":      "Lebar {field} harus {width} piksel",
+	"dimensions.min_width":  "Lebar {field} minimal {min_width} piksel",
+	"dimensions.max_width":  "Lebar {field} maksimal {max_width} piksel",
+	"dimensions.height":     "Tinggi {field} harus {height} piksel",
+	"dimensions.min_height": "Tinggi {field} minimal {min_height} piksel",
+	"dimensions.max_height": "Tinggi {field} maksimal {max_height} piksel",
+	RuleRatio:               "Rasio aspek {field} harus {ratio}",
+	RuleArchive:             "{field} harus berupa arsip ZIP",
+	RuleArchiveEntries:      "{field} maksimal berisi {max_entries} entri",
+	RuleArchiveSize:         "Ukuran {field} setelah diekstrak maksimal {max_size} byte",
+	RuleArchiveRatio:        "Entri {entry} pada {field} terkompresi lebih dari {max_ratio} kali",
+	RuleArchivePath:         "Entri {entry} pada {field} memiliki path yang tidak aman",
+	RuleArchiveExtensions:   "Entri {entry} pada {field} harus berupa berkas bertipe {extensions}",
+	"min.files":             "{field} minimal berisi {min} berkas",
+	"max.files":             "{field} maksimal berisi {max} berkas",
+	RuleMaxTotalSize:        "Ukuran total {field} maksimal {max_total_size} byte",
+	RuleDistinct:            "{field} sama dengan {other}",
+	RuleMinDigits:           "{field} minimal terdiri dari {min_digits} digit",
+	RuleMaxDigits:           "{field} maksimal terdiri dari {max_digits} digit",
+	RuleInteger:             "{field} harus berupa bilangan bulat",
+	"integer.slice":         "{field} hanya boleh berisi bilangan bulat",
+	RuleGreaterThan:         "{field} harus lebih besar dari {gt}",
+	RuleLessThan:            "{field} harus lebih kecil dari {lt}",
+	RuleMultipleOf:          "{field} harus kelipatan {multiple_of}",
+	RuleRegex:               "Format {field} tidak valid",
+	RuleNotRegex:            "Format {field} tidak valid",
+	RuleIn:                  "{field} harus salah satu dari {in}",
+	RuleNotIn:               "{field} tidak boleh salah satu dari {not_in}",
+	RuleEmail:               "{field} bukan alamat email yang valid",
+	RuleAlpha:               "{field} hanya boleh berisi huruf",
+	RuleAlphaNumeric:        "{field} hanya boleh berisi huruf dan angka",
+	RuleUrl:                 "{field} bukan URL yang valid",
+}
+
+// RegisterLocale adds the messages to the locale bundle with the given name,
+// creating the bundle when it does not exist yet. Keys missing from a
+// region specific bundle, e.g. "id-ID", fall back to its base language,
+// then to the templates of RegisterMessages and DefaultLocale.
+func RegisterLocale(locale string, messages Messages) {
+	locale = normalizeLocale(locale)
+	catalog.Lock()
+	defer catalog.Unlock()
+	bundle := Messages{}
+	for k, v := range catalog.locales[locale] {
+		bundle[k] = v
+	}
+	for k, v := range messages {
+		bundle[k] = v
+	}
+	catalog.locales[locale] = bundle
+}
+
+// LoadLocale reads a JSON object of message templates and registers it as
+// the given locale.
+func LoadLocale(locale string, r io.Reader) error {
+	var messages Messages
+	if err := json.NewDecoder(r).Decode(&messages); err != nil {
+		return fmt.Errorf("validet: load locale %s: %w", locale, err)
+	}
+	RegisterLocale(locale, messages)
+	return nil
+}
+
+// LoadLocaleFile registers the JSON message templates stored in a file as
+// the given locale.
+func LoadLocaleFile(locale string, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("validet: load locale %s: %w", locale, err)
+	}
+	defer f.Close()
+	return LoadLocale(locale, f)
+}
+
+// Locales returns the names of the registered locales.
+func Locales() []string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	var locales []string
+	for locale := range catalog.locales {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// LocaleFromAcceptLanguage picks the registered locale that best matches an
+// Accept-Language header, e.g. "id-ID,id;q=0.9,en;q=0.8". A region specific
+// tag falls back to its base language. DefaultLocale is returned when
+// nothing matches.
+func LocaleFromAcceptLanguage(header string) string {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{tag: normalizeLocale(tag), quality: quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	catalog.RLock()
+	defer catalog.RUnlock()
+	for _, c := range candidates {
+		if _, ok := catalog.locales[c.tag]; ok {
+			return c.tag
+		}
+		if base, _, ok := strings.Cut(c.tag, "-"); ok {
+			if _, ok := catalog.locales[base]; ok {
+				return base
+			}
+		}
+	}
+	return catalog.locale
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,224 @@
+package validet
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Messages is a catalog of message templates keyed by rule code, e.g.
+// "required", or by rule code and rule type, e.g. "min.string". The typed
+// key wins over the plain rule code.
+//
+// Templates may use the placeholders {field}, {path} and {value}, plus one
+// placeholder per rule parameter such as {min}, {max}, {in}, {other} and
+// {other_value}. Slice values are joined with ", ". A placeholder followed by
+// word forms, e.g. {min|character|characters}, renders the first form when
+// the value is 1 and the last form otherwise.
+type Messages map[string]string
+
+var defaultMessages = Messages{
+	RuleRequired:            "{field} is required",
+	RuleRequiredIf:          "{field} is required",
+	RuleRequiredUnless:      "{field} is required",
+	"type.string":           "{field} must be type of string",
+	"type.numeric":          "{field} must be type of {type}",
+	"type.coerce":           "{field} can't be converted to {type} without losing data",
+	"type.boolean":          "{field} must be type of boolean",
+	"type.object":           "{field} must be type of object",
+	"type.slice":            "{field} must be slice of type {type}",
+	"type.slice_object":     "{field} must be type of data object",
+	"type.file":             "{field} must be a type of file",
+	"type.files":            "{field} must be a list of files",
+	"min.string":            "{field} must be minimum of {min} {min|character|characters}",
+	"max.string":            "{field} must be maximum of {max} {max|character|characters}",
+	"min.numeric":           "{field} must be minimum of {min}",
+	"max.numeric":           "{field} must be maximum of {max}",
+	"min.slice":             "{field} must be minimum of {min}",
+	"max.slice":             "{field} must be maximum of {max}",
+	"min.slice_object":      "{field} must be minimum of {min}",
+	"max.slice_object":      "{field} must be maximum of {max}",
+	"min.file":              "{field} size must be at minimum {min}",
+	"max.file":              "{field} size must be at maximum {max}",
+	RuleMimes:               "{field} must be a file of type {mimes}",
+	RuleImage:               "{field} must be a PNG, JPEG or GIF image",
+	RuleImageFormat:         "{field} must be an image of format {formats}",
+	"dimensions.width":      "{field} must be {width} pixels wide",
+	"dimensions.min_width":  "{field} must be at least {min_width} pixels wide",
+	"dimensions.max_width":  "{field} must be at most {max_width} pixels wide",
+	"dimensions.height":     "{field} must be {height} pixels high",
+	"dimensions.min_height": "{field} must be at least {min_height} pixels high",
+	"dimensions.max_height": "{field} must be at most {max_height} pixels high",
+	RuleRatio:               "{field} must have an aspect ratio of {ratio}",
+	RuleArchive:             "{field} must be a ZIP archive",
+	RuleArchiveEntries:      "{field} must contain at most {max_entries} {max_entries|entry|entries}",
+	RuleArchiveSize:         "{field} uncompressed size must be at maximum {max_size}",
+	RuleArchiveRatio:        "{field} entry {entry} is compressed more than {max_ratio} times",
+	RuleArchivePath:         "{field} entry {entry} has an unsafe path",
+	RuleArchiveExtensions:   "{field} entry {entry} must be a file of type {extensions}",
+	"min.files":             "{field} must have at least {min} {min|file|files}",
+	"max.files":             "{field} must have at most {max} {max|file|files}",
+	RuleMaxTotalSize:        "{field} total size must be at maximum {max_total_size}",
+	RuleDistinct:            "{field} is a duplicate of {other}",
+	RuleMinDigits:           "{field} total digits must be minimum of {min_digits} {min_digits|digit|digits}",
+	RuleMaxDigits:           "{field} total digits must be maximum of {max_digits} {max_digits|digit|digits}",
+	RuleInteger:             "{field} must be an integer",
+	"integer.slice":         "{field} must only contain integers",
+	RuleGreaterThan:         "{field} must be greater than {gt}",
+	RuleLessThan:            "{field} must be less than {lt}",
+	RuleMultipleOf:          "{field} must be a multiple of {multiple_of}",
+	RuleRegex:               "{field} is not a valid format",
+	RuleNotRegex:            "{field} is not a valid format",
+	RuleIn:                  "{field} must in {in}",
+	RuleNotIn:               "{field} must not in {not_in}",
+	RuleEmail:               "{field} is not a valid email",
+	RuleAlpha:               "{field} is not an alphabetic value",
+	RuleAlphaNumeric:        "{field} is not an alphabetic number value",
+	RuleUrl:                 "{field} is not a valid url",
+}
+
+var catalog = struct {
+	sync.RWMutex
+	messages Messages
+	locales  map[string]Messages
+	locale   string
+}{
+	messages: Messages{},
+	locale:   LocaleEnglish,
+	locales: map[string]Messages{
+		LocaleEnglish:    defaultMessages,
+		LocaleIndonesian: indonesianMessages,
+	},
+}
+
+// RegisterMessages overrides the built-in message templates of
+// DefaultLocale. A validation selecting a locale with Options.Locale uses
+// the templates of that locale, or of its base language, first; the
+// registered templates fill the keys they miss. Options.Messages and the
+// per-rule Message fields still win over every catalog template.
+func RegisterMessages(messages Messages) {
+	catalog.Lock()
+	defer catalog.Unlock()
+	for k, v := range messages {
+		catalog.messages[k] = v
+	}
+}
+
+func (m Messages) lookup(keys ...string) (string, bool) {
+	for _, k := range keys {
+		if template, ok := m[k]; ok {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+func lookupMessage(messageKey string, option Options) string {
+	keys := []string{messageKey}
+	if rule, _, ok := strings.Cut(messageKey, "."); ok {
+		keys = append(keys, rule)
+	}
+	if template, ok := option.Messages.lookup(keys...); ok {
+		return template
+	}
+	catalog.RLock()
+	defer catalog.RUnlock()
+	if option.Locale != "" {
+		locale := normalizeLocale(option.Locale)
+		if template, ok := catalog.locales[locale].lookup(keys...); ok {
+			return template
+		}
+		if base, _, ok := strings.Cut(locale, "-"); ok {
+			if template, ok := catalog.locales[base].lookup(keys...); ok {
+				return template
+			}
+		}
+	}
+	if template, ok := catalog.messages.lookup(keys...); ok {
+		return template
+	}
+	if template, ok := catalog.locales[catalog.locale].lookup(keys...); ok {
+		return template
+	}
+	template, _ := catalog.locales[LocaleEnglish].lookup(keys...)
+	return template
+}
+
+func (e FieldError) render(field string, option Options) string {
+	template := e.template
+	if template == "" && e.messageKey != "" {
+		template = lookupMessage(e.messageKey, option)
+	}
+	if template == "" {
+		return e.Message
+	}
+	return formatMessage(template, e.placeholders(field, option))
+}
+
+func (e FieldError) placeholders(field string, option Options) map[string]string {
+	values := map[string]string{
+		"field": field,
+		"path":  strings.Join(e.Path, "."),
+		"value": formatMessageValue(e.Value),
+	}
+	for k, v := range e.Params {
+		values[k] = formatMessageValue(v)
+	}
+	if other, ok := e.Params["other"].(string); ok {
+		values["other"] = option.Attributes.name(strings.Split(other, "."), other)
+	}
+	return values
+}
+
+func formatMessage(template string, values map[string]string) string {
+	var message strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		message.WriteString(template[:start])
+		name, forms, plural := strings.Cut(template[start+1:end], "|")
+		if value, ok := values[name]; ok {
+			if plural {
+				message.WriteString(pluralForm(value, strings.Split(forms, "|")))
+			} else {
+				message.WriteString(value)
+			}
+		} else {
+			message.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	message.WriteString(template)
+	return message.String()
+}
+
+func pluralForm(value string, forms []string) string {
+	if n, err := strconv.ParseFloat(value, 64); err == nil && n == 1 {
+		return forms[0]
+	}
+	return forms[len(forms)-1]
+}
+
+func formatMessageValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		values := make([]string, rv.Len())
+		for i := range values {
+			values[i] = formatMessageValue(rv.Index(i).Interface())
+		}
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprint(value)
+}
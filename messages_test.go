@@ -0,0 +1,217 @@
+package validet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Message_Templates(t *testing.T) {
+	data := DataObject{"name": "ab", "information": DataObject{"role": "guest"}}
+
+	t.Run("it should render the placeholders of a per-rule message", func(t *testing.T) {
+		schema := NewSchema(data, SchemaRules{
+			"name": String{Min: 3, Message: StringErrorMessage{Min: "{field} must be at least {min} characters, got {value}"}},
+		}, Options{})
+		bags, _ := schema.Validate()
+		expected := "name must be at least 3 characters, got ab"
+		if bags.Errors["name"][0] != expected {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["name"][0], expected)
+		}
+	})
+	t.Run("it should use the options catalog over the built-in messages", func(t *testing.T) {
+		schema := NewSchema(data, SchemaRules{
+			"name": String{Min: 3},
+			"information": Object{Item: SchemaObject{
+				"role": String{In: []string{"admin", "member"}},
+			}},
+		}, Options{Messages: Messages{
+			"min.string": "{field} is too short",
+			RuleIn:       "{path} must be one of {in}",
+		}})
+		bags, _ := schema.Validate()
+		if bags.Errors["name"][0] != "name is too short" {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["name"][0], "name is too short")
+		}
+		expected := "information.role must be one of admin, member"
+		if bags.Errors["information.role"][0] != expected {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["information.role"][0], expected)
+		}
+	})
+	t.Run("it should prefer per-rule messages, then options, then the registered catalog", func(t *testing.T) {
+		RegisterMessages(Messages{RuleRequired: "{field} wajib diisi", RuleMin: "{field} terlalu pendek"})
+		t.Cleanup(func() { catalog.messages = Messages{} })
+
+		schema := NewSchema(DataObject{"name": "ab"}, SchemaRules{
+			"name":  String{Min: 3},
+			"email": String{Required: true},
+			"phone": String{Required: true, Message: StringErrorMessage{Required: "phone please"}},
+			"city":  String{Required: true},
+		}, Options{Messages: Messages{"required": "{field} must be filled"}})
+		bags, _ := schema.Validate()
+		expected := map[string]string{
+			"name":  "name terlalu pendek",
+			"email": "email must be filled",
+			"phone": "phone please",
+			"city":  "city must be filled",
+		}
+		for key, message := range expected {
+			if bags.Errors[key][0] != message {
+				t.Errorf("Actual = %v, Expected = %v", bags.Errors[key][0], message)
+			}
+		}
+	})
+}
+
+func Test_Locales(t *testing.T) {
+	rules := SchemaRules{
+		"name":  String{Min: 1, Max: 3},
+		"email": String{Required: true},
+	}
+	data := DataObject{"name": "tono"}
+
+	t.Run("it should use plural-aware english messages by default", func(t *testing.T) {
+		schema := NewSchema(DataObject{"name": "tono"}, SchemaRules{"name": String{Max: 1}}, Options{})
+		bags, _ := schema.Validate()
+		expected := "name must be maximum of 1 character"
+		if bags.Errors["name"][0] != expected {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["name"][0], expected)
+		}
+	})
+	t.Run("it should render the messages of the selected locale", func(t *testing.T) {
+		schema := NewSchema(data, rules, Options{Locale: LocaleIndonesian})
+		bags, _ := schema.Validate()
+		expected := map[string]string{
+			"name":  "name maksimal 3 karakter",
+			"email": "email wajib diisi",
+		}
+		for key, message := range expected {
+			if bags.Errors[key][0] != message {
+				t.Errorf("Actual = %v, Expected = %v", bags.Errors[key][0], message)
+			}
+		}
+	})
+	t.Run("it should load a custom locale from a JSON file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "fr.json")
+		if err := os.WriteFile(path, []byte(`{"required": "{field} est obligatoire"}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := LoadLocaleFile("fr", path); err != nil {
+			t.Fatalf("Actual = %v, Expected = %v", err, nil)
+		}
+		schema := NewSchema(data, rules, Options{Locale: "fr"})
+		bags, _ := schema.Validate()
+		if bags.Errors["email"][0] != "email est obligatoire" {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["email"][0], "email est obligatoire")
+		}
+		if bags.Errors["name"][0] != "name must be maximum of 3 characters" {
+			t.Errorf("Actual = %v, Expected the english fallback", bags.Errors["name"][0])
+		}
+	})
+}
+
+func Test_LocaleFallback(t *testing.T) {
+	rules := SchemaRules{"email": String{Required: true}}
+
+	t.Run("it should fall back from a region to its base language", func(t *testing.T) {
+		schema := NewSchema(DataObject{}, rules, Options{Locale: "id-ID"})
+		bags, _ := schema.Validate()
+		if bags.Errors["email"][0] != "email wajib diisi" {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["email"][0], "email wajib diisi")
+		}
+	})
+	t.Run("it should prefer the selected locale over the registered messages", func(t *testing.T) {
+		RegisterMessages(Messages{RuleRequired: "{field} is needed"})
+		t.Cleanup(func() { catalog.messages = Messages{} })
+
+		schema := NewSchema(DataObject{}, rules, Options{Locale: "id_ID"})
+		bags, _ := schema.Validate()
+		if bags.Errors["email"][0] != "email wajib diisi" {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["email"][0], "email wajib diisi")
+		}
+		schema = NewSchema(DataObject{}, rules, Options{})
+		bags, _ = schema.Validate()
+		if bags.Errors["email"][0] != "email is needed" {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["email"][0], "email is needed")
+		}
+	})
+	t.Run("it should use the locale set by SetDefaultLocale", func(t *testing.T) {
+		SetDefaultLocale("ID")
+		t.Cleanup(func() { SetDefaultLocale(LocaleEnglish) })
+
+		if DefaultLocale() != LocaleIndonesian {
+			t.Errorf("Actual = %v, Expected = %v", DefaultLocale(), LocaleIndonesian)
+		}
+		schema := NewSchema(DataObject{}, rules, Options{})
+		bags, _ := schema.Validate()
+		if bags.Errors["email"][0] != "email wajib diisi" {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors["email"][0], "email wajib diisi")
+		}
+		if actual := LocaleFromAcceptLanguage("de"); actual != LocaleIndonesian {
+			t.Errorf("Actual = %v, Expected = %v", actual, LocaleIndonesian)
+		}
+	})
+}
+
+func Test_LocaleFromAcceptLanguage(t *testing.T) {
+	cases := map[string]string{
+		"id-ID,id;q=0.9,en;q=0.8": LocaleIndonesian,
+		"en-US,en;q=0.9":          LocaleEnglish,
+		"de;q=0.9,id;q=0.5":       LocaleIndonesian,
+		"en;q=0.2,id;q=0.8":       LocaleIndonesian,
+		"de":                      DefaultLocale(),
+		"":                        DefaultLocale(),
+	}
+	for header, expected := range cases {
+		t.Run("it should pick "+expected+" for "+header, func(t *testing.T) {
+			if actual := LocaleFromAcceptLanguage(header); actual != expected {
+				t.Errorf("Actual = %v, Expected = %v", actual, expected)
+			}
+		})
+	}
+}
+
+func Test_Attributes(t *testing.T) {
+	schema := NewSchema(
+		DataObject{
+			"type": "company",
+			"information": DataObject{
+				"job": DataObject{"level": ""},
+			},
+			"items": []any{DataObject{"title": ""}, DataObject{"title": "pen"}},
+		},
+		SchemaRules{
+			"dob":          String{Required: true},
+			"company_name": String{RequiredIf: &RequiredIf{FieldPath: "type", Value: "company"}, Message: StringErrorMessage{RequiredIf: "{field} is required when {other} is {other_value}"}},
+			"information": Object{Item: SchemaObject{
+				"job": Object{Item: SchemaObject{
+					"level": String{Required: true},
+				}},
+			}},
+			"items": SliceObject{Item: SchemaObject{
+				"title": String{Required: true},
+			}},
+		},
+		Options{Attributes: Attributes{
+			"dob":                   "date of birth",
+			"type":                  "company type",
+			"company_name":          "company name",
+			"information.job.level": "job level",
+			"items.*.title":         "item title",
+		}},
+	)
+	bags, _ := schema.Validate()
+	expected := map[string]string{
+		"dob":                   "date of birth is required",
+		"company_name":          "company name is required when company type is company",
+		"information.job.level": "job level is required",
+		"items.0.title":         "item title is required",
+	}
+	for key, message := range expected {
+		t.Run("it should use the display name for "+key, func(t *testing.T) {
+			if len(bags.Errors[key]) == 0 || bags.Errors[key][0] != message {
+				t.Errorf("Actual = %v, Expected = %v", bags.Errors[key], message)
+			}
+		})
+	}
+}
@@ -43,10 +43,15 @@ type Numeric[NT NumericValue] struct {
 	In             []NT
 	NotIn          []NT
 	Custom         func(v NT, path PathKey, look Lookup) error
+	Default        any
 	Message        NumericErrorMessage
+
+	// regex and notRegex are compiled by Compile, see ruleRegex.
+	regex    *regexp.Regexp
+	notRegex *regexp.Regexp
 }
 
-func (s Numeric[NT]) isMyTypeOf(schema any) bool {
+func (s Numeric[NT]) IsMyTypeOf(schema any) bool {
 	return reflect.TypeOf(schema).Kind() == reflect.Struct &&
 		(reflect.TypeOf(schema) == reflect.TypeOf(Numeric[int]{}) ||
 			reflect.TypeOf(schema) == reflect.TypeOf(Numeric[int32]{}) ||
@@ -58,64 +63,32 @@ func (s Numeric[NT]) isMyTypeOf(schema any) bool {
 			reflect.TypeOf(schema) == reflect.TypeOf(Numeric[float64]{}))
 }
 
-func (s Numeric[NT]) process(params RuleParams) ([]string, error) {
-	schemaData := params.DataKey.(DataObject)
-	var err error
-	var bags []string
+func (s Numeric[NT]) Process(params RuleContext) ([]FieldError, error) {
+	return s.Validate(params.OriginalData, params.Value(), params)
+}
 
-	schema := params.Schema
-	originalData := params.OriginalData
+func (s Numeric[NT]) Validate(jsonSource []byte, value any, params RuleContext) ([]FieldError, error) {
+	var bags []FieldError
 	key := params.Key
+	option := params.Option
 
-	switch reflect.TypeOf(schema) {
-	case reflect.TypeOf(Numeric[int]{}):
-		if scMap, ok := schema.(Numeric[int]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Numeric[int32]{}):
-		if scMap, ok := schema.(Numeric[int32]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Numeric[int64]{}):
-		if scMap, ok := schema.(Numeric[int64]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Numeric[uint]{}):
-		if scMap, ok := schema.(Numeric[uint]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Numeric[uint32]{}):
-		if scMap, ok := schema.(Numeric[uint32]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Numeric[uint64]{}):
-		if scMap, ok := schema.(Numeric[uint64]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Numeric[float32]{}):
-		if scMap, ok := schema.(Numeric[float32]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Numeric[float64]{}):
-		if scMap, ok := schema.(Numeric[float64]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
+	if option.Coerce {
+		coerced, ok := coerceNumber(coerceEmpty(value), reflect.TypeOf(*new(NT)))
+		if !ok {
+			appendErrorBags(
+				&bags,
+				key,
+				FieldError{Rule: RuleType, Params: map[string]any{"type": fmt.Sprintf("%T", *new(NT))}, Value: value},
+				"type.coerce",
+				"",
+			)
+			return bags, NumericValidationError
 		}
+		value = coerced
+		setCoerced(params, value)
 	}
-	return bags, err
-	// pathKey := params.PathKey + key
-	// if err != nil {
-	// 	params.ErrorBags.append(pathKey, bags)
-	// 	if options.AbortEarly {
-	// 		return errors.New("error")
-	// 	}
-	// }
-	// return nil
-}
 
-func (s Numeric[NT]) validate(jsonSource []byte, value any, params RuleParams) ([]string, error) {
-	var bags []string
-	key := params.Key
-	option := params.Option
+	value = applyDefault(s.Default, value, params)
 
 	err := s.assertRequired(key, value, &bags)
 
@@ -193,24 +166,28 @@ func (s Numeric[NT]) validate(jsonSource []byte, value any, params RuleParams) (
 	return bags, nil
 }
 
-func (s Numeric[NT]) assertType(key string, value any, bags *[]string) (NT, error) {
+func (s Numeric[NT]) assertType(key string, value any, bags *[]FieldError) (NT, error) {
 	if numericValue, ok := value.(NT); ok {
 		return numericValue, nil
 	}
 	appendErrorBags(
 		bags,
-		fmt.Sprintf("%s must be type of %T", key, *new(NT)),
+		key,
+		FieldError{Rule: RuleType, Params: map[string]any{"type": fmt.Sprintf("%T", *new(NT))}, Value: value},
+		"type.numeric",
 		"",
 	)
 	return 0, NumericValidationError
 }
 
-func (s Numeric[NT]) assertRequired(key string, value any, bags *[]string) error {
+func (s Numeric[NT]) assertRequired(key string, value any, bags *[]FieldError) error {
 	if s.Required {
 		if value == nil {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return NumericValidationError
@@ -219,7 +196,9 @@ func (s Numeric[NT]) assertRequired(key string, value any, bags *[]string) error
 			if digitLength(parsedValue) == 0 {
 				appendErrorBags(
 					bags,
-					fmt.Sprintf("%s is required", key),
+					key,
+					FieldError{Rule: RuleRequired, Value: value},
+					"required",
 					s.Message.Required,
 				)
 				return NumericValidationError
@@ -229,25 +208,27 @@ func (s Numeric[NT]) assertRequired(key string, value any, bags *[]string) error
 	return nil
 }
 
-func (s Numeric[NT]) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]string) error {
+func (s Numeric[NT]) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]FieldError) error {
 	if s.RequiredIf != nil {
 		if value == nil {
-			comparedValue := gjson.GetBytes(jsonSource, s.RequiredIf.FieldPath)
-			if comparedValue.Value() == s.RequiredIf.Value {
+			if s.RequiredIf.matches(jsonSource) {
 				appendErrorBags(
 					bags,
-					fmt.Sprintf("%s is required", key),
+					key,
+					FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+					"required_if",
 					s.Message.RequiredIf,
 				)
 				return NumericValidationError
 			}
 		} else {
 			if parsedValue, ok := isNumericValue[NT](value); ok && digitLength(parsedValue) == 0 {
-				comparedValue := gjson.GetBytes(jsonSource, s.RequiredIf.FieldPath)
-				if comparedValue.Value() == s.RequiredIf.Value {
+				if s.RequiredIf.matches(jsonSource) {
 					appendErrorBags(
 						bags,
-						fmt.Sprintf("%s is required", key),
+						key,
+						FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+						"required_if",
 						s.Message.RequiredIf,
 					)
 					return NumericValidationError
@@ -258,25 +239,27 @@ func (s Numeric[NT]) assertRequiredIf(jsonSource []byte, key string, value any,
 	return nil
 }
 
-func (s Numeric[NT]) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]string) error {
+func (s Numeric[NT]) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]FieldError) error {
 	if s.RequiredUnless != nil {
 		if value == nil {
-			comparedValue := gjson.GetBytes(jsonSource, s.RequiredUnless.FieldPath)
-			if comparedValue.Value() != s.RequiredUnless.Value {
+			if !s.RequiredUnless.matches(jsonSource) {
 				appendErrorBags(
 					bags,
-					fmt.Sprintf("%s is required", key),
+					key,
+					FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+					"required_unless",
 					s.Message.RequiredUnless,
 				)
 				return NumericValidationError
 			}
 		} else {
 			if parsedValue, ok := isNumericValue[NT](value); ok && digitLength(parsedValue) == 0 {
-				comparedValue := gjson.GetBytes(jsonSource, s.RequiredUnless.FieldPath)
-				if comparedValue.Value() != s.RequiredUnless.Value {
+				if !s.RequiredUnless.matches(jsonSource) {
 					appendErrorBags(
 						bags,
-						fmt.Sprintf("%s is required", key),
+						key,
+						FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+						"required_unless",
 						s.Message.RequiredUnless,
 					)
 					return NumericValidationError
@@ -287,11 +270,13 @@ func (s Numeric[NT]) assertRequiredUnless(jsonSource []byte, key string, value a
 	return nil
 }
 
-func (s Numeric[NT]) assertMin(key string, value NT, bags *[]string) error {
+func (s Numeric[NT]) assertMin(key string, value NT, bags *[]FieldError) error {
 	if s.Min > 0 && value < NT(s.Min) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be minimum of %d", key, s.Min),
+			key,
+			FieldError{Rule: RuleMin, Params: map[string]any{"min": s.Min}, Value: value},
+			"min.numeric",
 			s.Message.Min,
 		)
 		return NumericValidationError
@@ -299,11 +284,13 @@ func (s Numeric[NT]) assertMin(key string, value NT, bags *[]string) error {
 	return nil
 }
 
-func (s Numeric[NT]) assertMax(key string, value NT, bags *[]string) error {
+func (s Numeric[NT]) assertMax(key string, value NT, bags *[]FieldError) error {
 	if s.Max > 0 && value > NT(s.Max) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be maximum of %d", key, s.Max),
+			key,
+			FieldError{Rule: RuleMax, Params: map[string]any{"max": s.Max}, Value: value},
+			"max.numeric",
 			s.Message.Max,
 		)
 		return NumericValidationError
@@ -311,11 +298,13 @@ func (s Numeric[NT]) assertMax(key string, value NT, bags *[]string) error {
 	return nil
 }
 
-func (s Numeric[NT]) assertMinDigits(key string, value NT, bags *[]string) error {
+func (s Numeric[NT]) assertMinDigits(key string, value NT, bags *[]FieldError) error {
 	if s.MinDigits > 0 && NT(digitLength(value)) < NT(s.MinDigits) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s total digits must be minimum of %d digit(s)", key, s.MinDigits),
+			key,
+			FieldError{Rule: RuleMinDigits, Params: map[string]any{"min_digits": s.MinDigits}, Value: value},
+			"min_digits",
 			s.Message.MinDigits,
 		)
 		return NumericValidationError
@@ -323,11 +312,13 @@ func (s Numeric[NT]) assertMinDigits(key string, value NT, bags *[]string) error
 	return nil
 }
 
-func (s Numeric[NT]) assertMaxDigits(key string, value NT, bags *[]string) error {
+func (s Numeric[NT]) assertMaxDigits(key string, value NT, bags *[]FieldError) error {
 	if s.MaxDigits > 0 && NT(digitLength(value)) > NT(s.MaxDigits) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s total digits must be maximum of %d digit(s)", key, s.MaxDigits),
+			key,
+			FieldError{Rule: RuleMaxDigits, Params: map[string]any{"max_digits": s.MaxDigits}, Value: value},
+			"max_digits",
 			s.Message.MaxDigits,
 		)
 		return NumericValidationError
@@ -335,12 +326,16 @@ func (s Numeric[NT]) assertMaxDigits(key string, value NT, bags *[]string) error
 	return nil
 }
 
-func (s Numeric[NT]) assertRegex(key string, value NT, bags *[]string) error {
-	regx, err := regexp.Compile(s.Regex)
-	if s.Regex != "" && (err != nil || !regx.MatchString(numericToString(value))) {
+func (s Numeric[NT]) assertRegex(key string, value NT, bags *[]FieldError) error {
+	if s.Regex == "" {
+		return nil
+	}
+	if regx, err := ruleRegex(s.regex, s.Regex); err != nil || !regx.MatchString(numericToString(value)) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s is not a valid format", key),
+			key,
+			FieldError{Rule: RuleRegex, Params: map[string]any{"regex": s.Regex}, Value: value},
+			"regex",
 			s.Message.Regex,
 		)
 		return NumericValidationError
@@ -348,12 +343,16 @@ func (s Numeric[NT]) assertRegex(key string, value NT, bags *[]string) error {
 	return nil
 }
 
-func (s Numeric[NT]) assertNotRegex(key string, value NT, bags *[]string) error {
-	regx, err := regexp.Compile(s.Regex)
-	if s.NotRegex != "" && digitLength(value) > 0 && (err != nil || regx.MatchString(numericToString(value))) {
+func (s Numeric[NT]) assertNotRegex(key string, value NT, bags *[]FieldError) error {
+	if s.NotRegex == "" || digitLength(value) == 0 {
+		return nil
+	}
+	if regx, err := ruleRegex(s.notRegex, s.NotRegex); err != nil || regx.MatchString(numericToString(value)) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s is not a valid format", key),
+			key,
+			FieldError{Rule: RuleNotRegex, Params: map[string]any{"not_regex": s.NotRegex}, Value: value},
+			"not_regex",
 			s.Message.NotRegex,
 		)
 		return NumericValidationError
@@ -361,15 +360,13 @@ func (s Numeric[NT]) assertNotRegex(key string, value NT, bags *[]string) error
 	return nil
 }
 
-func (s Numeric[NT]) assertIn(key string, value NT, bags *[]string) error {
+func (s Numeric[NT]) assertIn(key string, value NT, bags *[]FieldError) error {
 	if len(s.In) > 0 && digitLength(value) > 0 && !slices.Contains(s.In, value) {
-		var stringIn []string
-		for _, n := range s.In {
-			stringIn = append(stringIn, numericToString(n))
-		}
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must in %s", key, strings.Join(stringIn, ", ")),
+			key,
+			FieldError{Rule: RuleIn, Params: map[string]any{"in": s.In}, Value: value},
+			"in",
 			s.Message.In,
 		)
 		return NumericValidationError
@@ -377,15 +374,13 @@ func (s Numeric[NT]) assertIn(key string, value NT, bags *[]string) error {
 	return nil
 }
 
-func (s Numeric[NT]) assertNotIn(key string, value NT, bags *[]string) error {
-	if len(s.In) > 0 && digitLength(value) > 0 && slices.Contains(s.In, value) {
-		var stringNotIn []string
-		for _, n := range s.NotIn {
-			stringNotIn = append(stringNotIn, numericToString(n))
-		}
+func (s Numeric[NT]) assertNotIn(key string, value NT, bags *[]FieldError) error {
+	if len(s.NotIn) > 0 && digitLength(value) > 0 && slices.Contains(s.NotIn, value) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must not in %s", key, strings.Join(stringNotIn, ", ")),
+			key,
+			FieldError{Rule: RuleNotIn, Params: map[string]any{"not_in": s.NotIn}, Value: value},
+			"not_in",
 			s.Message.NotIn,
 		)
 		return NumericValidationError
@@ -393,14 +388,17 @@ func (s Numeric[NT]) assertNotIn(key string, value NT, bags *[]string) error {
 	return nil
 }
 
-func (s Numeric[NT]) assertCustomValidation(fc func(v NT, path PathKey, look Lookup) error, jsonSource []byte, value NT, path PathKey, bags *[]string) error {
+func (s Numeric[NT]) assertCustomValidation(fc func(v NT, path PathKey, look Lookup) error, jsonSource []byte, value NT, path PathKey, bags *[]FieldError) error {
 	err := fc(value, path, func(k string) gjson.Result {
 		return gjson.GetBytes(jsonSource, k)
 	})
 	if err != nil {
+		fe, messageKey := customFieldError(err, value)
 		appendErrorBags(
 			bags,
-			err.Error(),
+			path.Current,
+			fe,
+			messageKey,
 			s.Message.Custom,
 		)
 		return NumericValidationError
@@ -419,16 +417,16 @@ func isNumericValue[N NumericValue](value any) (N, bool) {
 	return 0, false
 }
 
-func numericToString[N NumericValue](value N) (stringValue string) {
-	switch reflect.TypeOf(value).String() {
-	case reflect.Int.String(), reflect.Int32.String(), reflect.Int64.String():
-		stringValue = strconv.Itoa(int(value))
-	case reflect.Float32.String():
-		stringValue = strconv.FormatFloat(float64(value), 'g', -1, 32)
-	case reflect.Float64.String():
-		stringValue = strconv.FormatFloat(float64(value), 'g', -1, 64)
+func numericToString[N NumericValue](value N) string {
+	switch v := any(value).(type) {
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case uint, uint32, uint64:
+		return strconv.FormatUint(uint64(value), 10)
 	}
-	return
+	return strconv.FormatInt(int64(value), 10)
 }
 
 func digitLength[N NumericValue](value N) int {
@@ -1,7 +1,6 @@
 package validet
 
 import (
-	"fmt"
 	"reflect"
 
 	"github.com/tidwall/gjson"
@@ -14,49 +13,62 @@ type ObjectErrorMessage struct {
 	Custom         string
 }
 
+// Object validates a nested object with the rules of Item, or of Fields to
+// keep their order. Only these fields are collected by Bind and Validated:
+// the other keys of the object, and every key of an Object declaring no
+// fields, are left out as they were never validated.
 type Object struct {
 	Required       bool
 	RequiredIf     *RequiredIf
 	RequiredUnless *RequiredUnless
 	Item           DataObject
+	Fields         OrderedRules
 	Custom         func(v DataObject, path PathKey, look Lookup) error
+	Default        any
 	Message        ObjectErrorMessage
 }
 
-func (s Object) isMyTypeOf(schema any) bool {
+func (s Object) IsMyTypeOf(schema any) bool {
 	return reflect.TypeOf(schema).Kind() == reflect.Struct && reflect.TypeOf(schema) == reflect.TypeOf(Object{})
 }
 
-func (s Object) process(params RuleParams) ([]string, error) {
-	schemaData := params.DataKey.(DataObject)
+func (s Object) Process(params RuleContext) ([]FieldError, error) {
+	value := applyDefault(s.Default, params.Value(), params)
 	// var err error
-	// var bags []string
+	// var bags []FieldError
 
-	errorBags := *params.ErrorBags
+	errorBags := params.ErrorBags
 	schema := params.Schema
 	originalData := params.OriginalData
 	key := params.Key
 	options := params.Option
 
 	if scObject, ok := schema.(Object); ok {
-		bags, err := scObject.validate(originalData, schemaData[key], params)
+		bags, err := scObject.Validate(originalData, value, params)
 		if err != nil {
 			return bags, err
 		} else {
-			schemaDataValue := schemaData[key].(DataObject)
-			for scObjItemKey, scObjItemValue := range scObject.Item {
+			schemaDataValue, _ := value.(DataObject)
+			fields := itemFields(scObject.Fields, scObject.Item)
+			var output DataObject
+			if params.Output != nil && schemaDataValue != nil {
+				output = DataObject{}
+				params.Output[key] = output
+			}
+			for _, field := range fields {
 				mapSchemas(
 					originalData,
 					append(params.PathKey, key),
-					scObjItemKey,
+					field.Key,
 					schemaDataValue,
-					scObjItemValue,
-					&errorBags,
+					field.Rule,
+					errorBags,
 					options,
+					output,
 				)
-				// if options.AbortEarly && len(errorBags.Errors) > 0 {
-				// 	return errors.New("error")
-				// }
+				if options.AbortEarly && len(errorBags.Errors) > 0 {
+					break
+				}
 			}
 		}
 	}
@@ -68,15 +80,17 @@ func (s Object) process(params RuleParams) ([]string, error) {
 	// 		return errors.New("error")
 	// 	}
 	// }
-	return []string{}, nil
+	return nil, nil
 }
 
-func (s Object) validate(jsonSource []byte, value any, params RuleParams) ([]string, error) {
-	var bags []string
+func (s Object) Validate(jsonSource []byte, value any, params RuleContext) ([]FieldError, error) {
+	var bags []FieldError
 
 	key := params.Key
 	option := params.Option
 
+	value = applyDefault(s.Default, value, params)
+
 	err := s.assertRequired(key, value, &bags)
 
 	if err != nil {
@@ -118,14 +132,16 @@ func (s Object) validate(jsonSource []byte, value any, params RuleParams) ([]str
 
 }
 
-func (s Object) assertType(key string, value any, bags *[]string) (DataObject, error) {
+func (s Object) assertType(key string, value any, bags *[]FieldError) (DataObject, error) {
 	var objetcValue DataObject
 	if isObjectValue(value) {
 		objetcValue = value.(DataObject)
 	} else {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be type of object", key),
+			key,
+			FieldError{Rule: RuleType, Params: map[string]any{"type": "object"}, Value: value},
+			"type.object",
 			"",
 		)
 		return DataObject{}, StringValidationError
@@ -133,12 +149,14 @@ func (s Object) assertType(key string, value any, bags *[]string) (DataObject, e
 	return objetcValue, nil
 }
 
-func (s Object) assertRequired(key string, value any, bags *[]string) error {
+func (s Object) assertRequired(key string, value any, bags *[]FieldError) error {
 	if s.Required {
 		if value == nil {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return ObjectValidationError
@@ -147,7 +165,9 @@ func (s Object) assertRequired(key string, value any, bags *[]string) error {
 			if len(values) == 0 {
 				appendErrorBags(
 					bags,
-					fmt.Sprintf("%s is required", key),
+					key,
+					FieldError{Rule: RuleRequired, Value: value},
+					"required",
 					s.Message.Required,
 				)
 				return ObjectValidationError
@@ -155,7 +175,9 @@ func (s Object) assertRequired(key string, value any, bags *[]string) error {
 		} else {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s must be type of %T", key, *new(DataObject)),
+				key,
+				FieldError{Rule: RuleType, Params: map[string]any{"type": "object"}, Value: value},
+				"type.object",
 				s.Message.Required,
 			)
 			return ObjectValidationError
@@ -164,14 +186,15 @@ func (s Object) assertRequired(key string, value any, bags *[]string) error {
 	return nil
 }
 
-func (s Object) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]string) error {
-	values := value.(DataObject)
+func (s Object) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]FieldError) error {
+	values, _ := value.(DataObject)
 	if s.RequiredIf != nil && (value == nil || len(values) == 0) {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredIf.FieldPath)
-		if comparedValue.String() == s.RequiredIf.Value {
+		if s.RequiredIf.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+				"required_if",
 				s.Message.RequiredIf,
 			)
 			return ObjectValidationError
@@ -180,14 +203,15 @@ func (s Object) assertRequiredIf(jsonSource []byte, key string, value any, bags
 	return nil
 }
 
-func (s Object) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]string) error {
-	values := value.(DataObject)
+func (s Object) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]FieldError) error {
+	values, _ := value.(DataObject)
 	if s.RequiredUnless != nil && (value == nil || len(values) == 0) {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredUnless.FieldPath)
-		if comparedValue.String() != s.RequiredUnless.Value {
+		if !s.RequiredUnless.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+				"required_unless",
 				s.Message.RequiredUnless,
 			)
 			return ObjectValidationError
@@ -196,14 +220,17 @@ func (s Object) assertRequiredUnless(jsonSource []byte, key string, value any, b
 	return nil
 }
 
-func (s Object) assertCustomValidation(fc func(v DataObject, path PathKey, look Lookup) error, jsonSource []byte, value DataObject, path PathKey, bags *[]string) error {
+func (s Object) assertCustomValidation(fc func(v DataObject, path PathKey, look Lookup) error, jsonSource []byte, value DataObject, path PathKey, bags *[]FieldError) error {
 	err := fc(value, path, func(k string) gjson.Result {
 		return gjson.GetBytes(jsonSource, k)
 	})
 	if err != nil {
+		fe, messageKey := customFieldError(err, value)
 		appendErrorBags(
 			bags,
-			err.Error(),
+			path.Current,
+			fe,
+			messageKey,
 			s.Message.Custom,
 		)
 		return ObjectValidationError
@@ -0,0 +1,152 @@
+package validet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OpenAPISchema is a named schema of the OpenAPI components generated by
+// OpenAPIComponents.
+//
+// Descriptions and Examples are keyed by field path, using "*" for the
+// items of a SliceObject, e.g. "address.city" or "items.*.title". Example is
+// an example of the whole object.
+type OpenAPISchema struct {
+	Name         string
+	Rules        SchemaRules
+	Fields       OrderedRules
+	Description  string
+	Example      DataObject
+	Descriptions map[string]string
+	Examples     map[string]any
+}
+
+// OpenAPIComponents generates an OpenAPI 3.1 components object holding a
+// schema per OpenAPISchema, exported like JSONSchema. Schemas with top level
+// files (format: binary), or arrays of files, also get a multipart/form-data
+// request body of the same name, with the content type of each file in its
+// encoding.
+func OpenAPIComponents(schemas ...OpenAPISchema) (DataObject, error) {
+	components := DataObject{}
+	definitions := DataObject{}
+	requestBodies := DataObject{}
+	for _, schema := range schemas {
+		if schema.Name == "" {
+			return nil, fmt.Errorf("validet: openapi: schema without a name")
+		}
+		if _, ok := definitions[schema.Name]; ok {
+			return nil, fmt.Errorf("validet: openapi: duplicate schema %s", schema.Name)
+		}
+		fields := schema.Fields
+		if fields == nil {
+			fields = sortedFields(schema.Rules)
+		}
+		definition := JSONSchemaOrdered(fields)
+		delete(definition, "$schema")
+		if schema.Description != "" {
+			definition["description"] = schema.Description
+		}
+		if schema.Example != nil {
+			definition["examples"] = []any{schema.Example}
+		}
+		for path, description := range schema.Descriptions {
+			property, err := openAPIProperty(definition, path)
+			if err != nil {
+				return nil, fmt.Errorf("validet: openapi %s: %w", schema.Name, err)
+			}
+			property["description"] = description
+		}
+		for path, example := range schema.Examples {
+			property, err := openAPIProperty(definition, path)
+			if err != nil {
+				return nil, fmt.Errorf("validet: openapi %s: %w", schema.Name, err)
+			}
+			property["examples"] = []any{example}
+		}
+		definitions[schema.Name] = definition
+		if body, ok := multipartRequestBody(schema.Name, definition); ok {
+			requestBodies[schema.Name] = body
+		}
+	}
+	components["schemas"] = definitions
+	if len(requestBodies) > 0 {
+		components["requestBodies"] = requestBodies
+	}
+	return components, nil
+}
+
+// OpenAPIJSON returns the components of OpenAPIComponents as an indented
+// JSON document, ready to be merged into a specification.
+func OpenAPIJSON(schemas ...OpenAPISchema) ([]byte, error) {
+	components, err := OpenAPIComponents(schemas...)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(DataObject{"components": components}, "", "  ")
+}
+
+// OpenAPIYAML returns the components of OpenAPIComponents as a YAML
+// document, ready to be merged into a specification.
+func OpenAPIYAML(schemas ...OpenAPISchema) ([]byte, error) {
+	components, err := OpenAPIComponents(schemas...)
+	if err != nil {
+		return nil, err
+	}
+	return marshalYAML(DataObject{"components": components})
+}
+
+// openAPIProperty finds the schema of a field path in an exported schema.
+func openAPIProperty(schema DataObject, path string) (DataObject, error) {
+	property := schema
+	for _, segment := range strings.Split(path, ".") {
+		var next DataObject
+		if segment == "*" {
+			next, _ = property["items"].(DataObject)
+		} else if properties, ok := property["properties"].(DataObject); ok {
+			next, _ = properties[segment].(DataObject)
+		}
+		if next == nil {
+			return nil, fmt.Errorf("unknown field %s", path)
+		}
+		property = next
+	}
+	return property, nil
+}
+
+func multipartRequestBody(name string, schema DataObject) (DataObject, bool) {
+	properties, _ := schema["properties"].(DataObject)
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	binary := false
+	encoding := DataObject{}
+	for _, key := range keys {
+		property, _ := properties[key].(DataObject)
+		if items, ok := property["items"].(DataObject); ok && property["type"] == "array" {
+			property = items
+		}
+		if property["format"] != "binary" {
+			continue
+		}
+		binary = true
+		if contentType, ok := property["contentMediaType"].(string); ok {
+			encoding[key] = DataObject{"contentType": contentType}
+		}
+	}
+	if !binary {
+		return nil, false
+	}
+	media := DataObject{"schema": DataObject{"$ref": "#/components/schemas/" + name}}
+	if len(encoding) > 0 {
+		media["encoding"] = encoding
+	}
+	return DataObject{
+		"required": true,
+		"content":  DataObject{"multipart/form-data": media},
+	}, true
+}
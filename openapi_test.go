@@ -0,0 +1,165 @@
+package validet
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func openAPITestSchemas() []OpenAPISchema {
+	return []OpenAPISchema{
+		{
+			Name: "CreateUser",
+			Fields: OrderedRules{
+				{"name", String{Required: true, Max: 50}},
+				{"avatar", File{Mimes: []string{"image/png"}}},
+				{"addresses", SliceObject{Item: SchemaObject{
+					"city": String{Required: true},
+				}}},
+			},
+			Description:  "A new user.",
+			Descriptions: map[string]string{"name": "Full name: first and last", "addresses.*.city": "City"},
+			Examples:     map[string]any{"name": "Tono", "addresses.*.city": "Jakarta"},
+		},
+		{
+			Name:    "Login",
+			Rules:   SchemaRules{"email": String{Required: true, Email: true}, "remember": Boolean{}},
+			Example: DataObject{"email": "tono@mail.com", "remember": true},
+		},
+	}
+}
+
+func Test_OpenAPI(t *testing.T) {
+	t.Run("it should generate the components as yaml", func(t *testing.T) {
+		document, err := OpenAPIYAML(openAPITestSchemas()...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `components:
+  requestBodies:
+    CreateUser:
+      content:
+        multipart/form-data:
+          encoding:
+            avatar:
+              contentType: image/png
+          schema:
+            $ref: '#/components/schemas/CreateUser'
+      required: true
+  schemas:
+    CreateUser:
+      description: A new user.
+      properties:
+        addresses:
+          items:
+            properties:
+              city:
+                description: City
+                examples:
+                  - Jakarta
+                minLength: 1
+                type: string
+            required:
+              - city
+            type: object
+          type: array
+        avatar:
+          contentMediaType: image/png
+          format: binary
+          type: string
+        name:
+          description: 'Full name: first and last'
+          examples:
+            - Tono
+          maxLength: 50
+          minLength: 1
+          type: string
+      required:
+        - name
+      type: object
+    Login:
+      examples:
+        - email: tono@mail.com
+          remember: true
+      properties:
+        email:
+          format: email
+          minLength: 1
+          type: string
+        remember:
+          type: boolean
+      required:
+        - email
+      type: object
+`
+		if string(document) != expected {
+			t.Errorf("Actual = %s, Expected = %s", document, expected)
+		}
+	})
+
+	t.Run("it should generate the same components as json", func(t *testing.T) {
+		document, err := OpenAPIJSON(openAPITestSchemas()...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		components, _ := OpenAPIComponents(openAPITestSchemas()...)
+		var actual, expected any
+		json.Unmarshal(document, &actual)
+		source, _ := json.Marshal(DataObject{"components": components})
+		json.Unmarshal(source, &expected)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Actual = %s, Expected = %s", document, source)
+		}
+	})
+
+	t.Run("it should report unknown fields", func(t *testing.T) {
+		_, err := OpenAPIComponents(OpenAPISchema{
+			Name:         "Login",
+			Rules:        SchemaRules{"email": String{}},
+			Descriptions: map[string]string{"password": "Password"},
+		})
+		if err == nil || !strings.Contains(err.Error(), "unknown field password") {
+			t.Errorf("expected an unknown field error, got %v", err)
+		}
+	})
+}
+
+func Test_MarshalYAML(t *testing.T) {
+	document, err := marshalYAML(DataObject{
+		"plain":   "hello world",
+		"quoted":  []any{"", "true", "10", "- item", "a: b", "line\nbreak", "<a> #b"},
+		"numbers": []any{1, 2.5, nil},
+		"empty":   DataObject{"map": DataObject{}, "list": []any{}},
+		"nested":  []any{[]any{"a"}, DataObject{"b": DataObject{"c": 1}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `empty:
+  list: []
+  map: {}
+nested:
+  - - a
+  - b:
+      c: 1
+numbers:
+  - 1
+  - 2.5
+  - null
+plain: hello world
+quoted:
+  - ""
+  - "true"
+  - "10"
+  - '- item'
+  - 'a: b'
+  - |-
+    line
+    break
+  - '<a> #b'
+`
+	if string(document) != expected {
+		t.Errorf("Actual = %s, Expected = %s", document, expected)
+	}
+}
@@ -0,0 +1,277 @@
+package validet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ParseRules converts Laravel style rule strings keyed by field path into
+// rules, e.g.
+//
+//	validet.ParseRules(map[string]string{
+//		"name":          "required|string|min:3|max:50",
+//		"email":         "required|email",
+//		"company":       "required_if:type,company",
+//		"age":           "integer|min:17",
+//		"tags":          "array|max:3",
+//		"tags.*":        "string",
+//		"items":         "required|array|min:1",
+//		"items.*.title": "required|string",
+//	})
+//
+// Rules are separated by "|" (use "\|" for a literal pipe) and arguments
+// follow a colon, with list items separated by commas. The type rules
+// string, integer, numeric, boolean and array pick the rule; fields without
+// one are strings. integer and numeric both give a Numeric[float64], the
+// type of the numbers decoded from JSON, integer only accepting whole
+// numbers and reporting the others with RuleInteger. Dotted keys declare
+// the fields of an Object and keys with a "*" segment the items of a
+// SliceObject, or the item type of a Slice when the wildcard is the last
+// segment. nullable and sometimes are accepted and ignored, since absent
+// values are only checked by the required rules anyway.
+//
+// Unknown rules, rules that don't apply to the type and bad arguments are
+// reported with the field and the position of the rule.
+func ParseRules(rules map[string]string) (SchemaRules, error) {
+	root := &pipeNode{}
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		node := root
+		for _, segment := range strings.Split(key, ".") {
+			if segment == "" {
+				return nil, fmt.Errorf("validet: %s: empty path segment", key)
+			}
+			node = node.child(segment)
+		}
+		node.rules = rules[key]
+	}
+
+	if root.items != nil {
+		return nil, errors.New("validet: *: the data must be an object")
+	}
+	item, err := root.fields()
+	if err != nil {
+		return nil, err
+	}
+	schema := SchemaRules{}
+	for key, rule := range item {
+		schema[key] = rule.(Rule)
+	}
+	return schema, nil
+}
+
+// ParseRule converts a single Laravel style rule string into a rule, like
+// the rules of a ParseRules field without nested fields.
+func ParseRule(rule string) (Rule, error) {
+	node := &pipeNode{key: "rule", rules: rule}
+	return node.rule()
+}
+
+type pipeNode struct {
+	key      string
+	rules    string
+	children map[string]*pipeNode
+	order    []string
+	items    *pipeNode
+}
+
+func (n *pipeNode) child(segment string) *pipeNode {
+	key := segment
+	if n.key != "" {
+		key = n.key + "." + segment
+	}
+	if segment == "*" {
+		if n.items == nil {
+			n.items = &pipeNode{key: key}
+		}
+		return n.items
+	}
+	if n.children == nil {
+		n.children = map[string]*pipeNode{}
+	}
+	child, ok := n.children[segment]
+	if !ok {
+		child = &pipeNode{key: key}
+		n.children[segment] = child
+		n.order = append(n.order, segment)
+	}
+	return child
+}
+
+func (n *pipeNode) fields() (SchemaObject, error) {
+	item := SchemaObject{}
+	for _, segment := range n.order {
+		rule, err := n.children[segment].rule()
+		if err != nil {
+			return nil, err
+		}
+		item[segment] = rule
+	}
+	return item, nil
+}
+
+// specs splits the rule string and returns the rules with the type, which
+// is "" when none is declared.
+func (n *pipeNode) specs() ([]ruleSpec, ruleSpec, error) {
+	var specs []ruleSpec
+	var kind ruleSpec
+	for i, part := range splitEscaped(n.rules, '|') {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, ":")
+		spec := ruleSpec{name: strings.TrimSpace(name), arg: arg, sep: ",", pos: i}
+		switch spec.name {
+		case "string", "integer", "numeric", "boolean", "array":
+			if kind.name != "" {
+				return nil, kind, n.err(spec.err(fmt.Errorf("the type is already %s", kind.name)))
+			}
+			kind = spec
+		case "nullable", "sometimes":
+		case "regex", "not_regex":
+			spec.arg = pipePattern(arg)
+			specs = append(specs, spec)
+		default:
+			specs = append(specs, spec)
+		}
+	}
+	return specs, kind, nil
+}
+
+// pipePattern removes the delimiters of a PHP style pattern, "/^[a-z]+$/".
+func pipePattern(arg string) string {
+	if len(arg) >= 2 && arg[0] == '/' && arg[len(arg)-1] == '/' {
+		return arg[1 : len(arg)-1]
+	}
+	return arg
+}
+
+func (n *pipeNode) err(err error) error {
+	return fmt.Errorf("validet: %s: %w", n.key, err)
+}
+
+func (n *pipeNode) rule() (Rule, error) {
+	specs, kind, err := n.specs()
+	if err != nil {
+		return nil, err
+	}
+
+	var rule Rule
+	switch {
+	case n.items != nil && n.children != nil:
+		return nil, n.err(errors.New("fields and items (*) can't be mixed"))
+	case n.items != nil || n.children != nil:
+		if kind.name != "" && kind.name != "array" {
+			return nil, n.err(kind.err(errors.New("fields with nested rules must be arrays")))
+		}
+		if n.children != nil {
+			item, err := n.fields()
+			if err != nil {
+				return nil, err
+			}
+			rule, err = objectRule(specs, item)
+			if err != nil {
+				return nil, n.err(err)
+			}
+			return rule, nil
+		}
+		return n.slice(specs)
+	}
+
+	switch kind.name {
+	case "", "string":
+		rule, err = stringRule(specs)
+	case "integer":
+		var numeric Numeric[float64]
+		numeric, err = numericRule[float64](specs)
+		numeric.Custom = integerNumber
+		rule = numeric
+	case "numeric":
+		rule, err = numericRule[float64](specs)
+	case "boolean":
+		rule, err = booleanRule(specs)
+	case "array":
+		return nil, n.err(kind.err(fmt.Errorf("declare the items with %s.*", n.key)))
+	}
+	if err != nil {
+		return nil, n.err(err)
+	}
+	return rule, nil
+}
+
+// integerNumber is the Custom func of the integer rules. They hold float64
+// values, the type of the numbers decoded from JSON, and only accept the
+// whole ones.
+func integerNumber(v float64, _ PathKey, _ Lookup) error {
+	if v != math.Trunc(v) {
+		return FieldError{Rule: RuleInteger}
+	}
+	return nil
+}
+
+// integerNumbers is the Custom func of the slices of integers.
+func integerNumbers(v []float64, _ PathKey, _ Lookup) error {
+	for _, n := range v {
+		if n != math.Trunc(n) {
+			return FieldError{Rule: RuleInteger, messageKey: "integer.slice"}
+		}
+	}
+	return nil
+}
+
+// slice builds the rule of an array whose items are declared with a "*"
+// key: a SliceObject when the items have fields and a Slice otherwise.
+func (n *pipeNode) slice(specs []ruleSpec) (Rule, error) {
+	items := n.items
+	itemSpecs, itemKind, err := items.specs()
+	if err != nil {
+		return nil, err
+	}
+	if items.children != nil || items.items != nil {
+		if len(itemSpecs) > 0 || itemKind.name != "" && itemKind.name != "array" {
+			return nil, items.err(errors.New("only the array type is supported on items with fields"))
+		}
+		if items.items != nil {
+			return nil, items.err(errors.New("nested arrays are not supported"))
+		}
+		item, err := items.fields()
+		if err != nil {
+			return nil, err
+		}
+		rule, err := sliceObjectRule(specs, item)
+		if err != nil {
+			return nil, n.err(err)
+		}
+		return rule, nil
+	}
+
+	if len(itemSpecs) > 0 {
+		return nil, items.err(itemSpecs[0].err(errors.New("only the item type is supported on slice items")))
+	}
+	var rule Rule
+	switch itemKind.name {
+	case "", "string":
+		rule, err = sliceRule[string](specs)
+	case "integer":
+		var slice Slice[float64]
+		slice, err = sliceRule[float64](specs)
+		slice.Custom = integerNumbers
+		rule = slice
+	case "numeric":
+		rule, err = sliceRule[float64](specs)
+	default:
+		return nil, items.err(itemKind.err(errors.New("slices only hold strings, integers or numbers")))
+	}
+	if err != nil {
+		return nil, n.err(err)
+	}
+	return rule, nil
+}
@@ -0,0 +1,123 @@
+package validet
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ParseRules(t *testing.T) {
+	t.Run("it should convert pipe rule strings into rules", func(t *testing.T) {
+		rules, err := ParseRules(map[string]string{
+			"name":          "required|string|min:3|max:50",
+			"email":         "required|email",
+			"code":          `regex:/^[a-z]{2}\|[0-9]+$/`,
+			"site":          "nullable|url:https",
+			"type":          "in:person,company",
+			"company":       "required_if:type,company",
+			"nik":           "required_unless:type, company",
+			"age":           "integer|min:17|not_in:20,21",
+			"score":         "numeric|max_digits:3",
+			"active":        "boolean",
+			"tags":          "array|max:3",
+			"tags.*":        "string",
+			"ids.*":         "integer",
+			"address.city":  "required|alpha",
+			"items":         "required|array|min:1",
+			"items.*.title": "required|string",
+			"items.*.qty":   "integer|min:1",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The integer rules check the whole numbers with a Custom func,
+		// which reflect.DeepEqual can't compare.
+		age, _ := rules["age"].(Numeric[float64])
+		ids, _ := rules["ids"].(Slice[float64])
+		items, _ := rules["items"].(SliceObject)
+		qty, _ := items.Item["qty"].(Numeric[float64])
+		if age.Custom == nil || ids.Custom == nil || qty.Custom == nil {
+			t.Fatalf("Actual = %#v, Expected the integer rules to have a Custom func", rules)
+		}
+		age.Custom, ids.Custom, qty.Custom = nil, nil, nil
+		rules["age"], rules["ids"], items.Item["qty"] = age, ids, qty
+		expected := SchemaRules{
+			"name":    String{Required: true, Min: 3, Max: 50},
+			"email":   String{Required: true, Email: true},
+			"code":    String{Regex: `^[a-z]{2}|[0-9]+$`},
+			"site":    String{Url: &Url{Https: true}},
+			"type":    String{In: []string{"person", "company"}},
+			"company": String{RequiredIf: &RequiredIf{FieldPath: "type", Value: "company"}},
+			"nik":     String{RequiredUnless: &RequiredUnless{FieldPath: "type", Value: "company"}},
+			"age":     Numeric[float64]{Min: 17, NotIn: []float64{20, 21}},
+			"score":   Numeric[float64]{MaxDigits: 3},
+			"active":  Boolean{},
+			"tags":    Slice[string]{Max: 3},
+			"ids":     Slice[float64]{},
+			"address": Object{Item: SchemaObject{"city": String{Required: true, Alpha: true}}},
+			"items": SliceObject{Required: true, Min: 1, Item: SchemaObject{
+				"title": String{Required: true},
+				"qty":   Numeric[float64]{Min: 1},
+			}},
+		}
+		if !reflect.DeepEqual(rules, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rules, expected)
+		}
+	})
+
+	t.Run("it should validate the integers decoded from JSON", func(t *testing.T) {
+		rules, err := ParseRules(map[string]string{"age": "required|integer|min:17", "ids.*": "integer"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bags, err := ValidateJSON([]byte(`{"age": 20, "ids": [1, 2]}`), rules, Options{}); err != nil {
+			t.Errorf("Actual = %v, Expected no errors", bags.Errors)
+		}
+		bags, _ := ValidateJSON([]byte(`{"age": 20.5, "ids": [1, 2.5]}`), rules, Options{})
+		expected := map[string][]string{
+			"age": {"age must be an integer"},
+			"ids": {"ids must only contain integers"},
+		}
+		if !reflect.DeepEqual(bags.Errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, expected)
+		}
+		if bags.Fields["age"][0].Rule != RuleInteger {
+			t.Errorf("Actual = %v, Expected = %v", bags.Fields["age"][0].Rule, RuleInteger)
+		}
+	})
+
+	t.Run("it should parse a single rule string", func(t *testing.T) {
+		rule, err := ParseRule("required|numeric|in:1.5,2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := Numeric[float64]{Required: true, In: []float64{1.5, 2}}
+		if !reflect.DeepEqual(rule, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", rule, expected)
+		}
+	})
+
+	t.Run("it should report bad rules with their position", func(t *testing.T) {
+		tests := []struct {
+			rules    map[string]string
+			expected string
+		}{
+			{map[string]string{"name": "required|strng"}, `validet: name: rule "strng" at position 2: unknown rule`},
+			{map[string]string{"age": "required|integer|email"}, `validet: age: rule "email" at position 3: rule is not supported for this type`},
+			{map[string]string{"name": "string|min:abc"}, `validet: name: rule "min" at position 2: invalid integer argument "abc"`},
+			{map[string]string{"age": "integer|in:1,a"}, `validet: age: rule "in" at position 2: invalid float64 value "a"`},
+			{map[string]string{"company": "required_if:type"}, `validet: company: rule "required_if" at position 1: expected a field and a value`},
+			{map[string]string{"name": "string|integer"}, `validet: name: rule "integer" at position 2: the type is already string`},
+			{map[string]string{"tags": "array"}, `validet: tags: rule "array" at position 1: declare the items with tags.*`},
+			{map[string]string{"tags.*": "string|min:3"}, `validet: tags.*: rule "min" at position 2: only the item type is supported`},
+			{map[string]string{"tags": "string", "tags.*": "string"}, `validet: tags: rule "string" at position 1: fields with nested rules must be arrays`},
+			{map[string]string{"items.*": "string", "items.name": "string"}, `validet: items: fields and items (*) can't be mixed`},
+		}
+		for _, tt := range tests {
+			_, err := ParseRules(tt.rules)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Actual = %v, Expected to contain = %s", err, tt.expected)
+			}
+		}
+	})
+}
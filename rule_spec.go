@@ -0,0 +1,331 @@
+package validet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ruleSpec is a single textual rule such as "min=3" or "in:a,b", shared by
+// every text based way of declaring rules. pos is the index of the rule in
+// its declaration, or -1 when it has none.
+type ruleSpec struct {
+	name string
+	arg  string
+	sep  string
+	pos  int
+}
+
+var errUnknownRule = errors.New("unknown rule")
+var errUnsupportedRule = errors.New("rule is not supported for this type")
+var errMissingArgument = errors.New("rule requires an argument")
+
+func (r ruleSpec) list() []string {
+	var values []string
+	if r.sep == " " {
+		values = strings.Fields(r.arg)
+	} else {
+		for _, v := range strings.Split(r.arg, r.sep) {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+func (r ruleSpec) int() (int, error) {
+	if r.arg == "" {
+		return 0, errMissingArgument
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(r.arg))
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer argument %q", r.arg)
+	}
+	return n, nil
+}
+
+func (r ruleSpec) condition() (string, string, error) {
+	var parts []string
+	if r.sep == " " {
+		parts = strings.SplitN(strings.TrimSpace(r.arg), " ", 2)
+	} else {
+		parts = strings.SplitN(r.arg, r.sep, 2)
+	}
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return "", "", fmt.Errorf("expected a field and a value, got %q", r.arg)
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), nil
+}
+
+func (r ruleSpec) err(err error) error {
+	if r.pos < 0 {
+		return fmt.Errorf("rule %q: %w", r.name, err)
+	}
+	return fmt.Errorf("rule %q at position %d: %w", r.name, r.pos+1, err)
+}
+
+type presenceSpec struct {
+	required       bool
+	requiredIf     *RequiredIf
+	requiredUnless *RequiredUnless
+}
+
+// apply handles the rules shared by every rule type and reports whether the
+// spec was consumed.
+func (p *presenceSpec) apply(spec ruleSpec) (bool, error) {
+	switch spec.name {
+	case "required":
+		p.required = true
+	case "required_if":
+		field, value, err := spec.condition()
+		if err != nil {
+			return true, err
+		}
+		p.requiredIf = &RequiredIf{FieldPath: field, Value: value}
+	case "required_unless":
+		field, value, err := spec.condition()
+		if err != nil {
+			return true, err
+		}
+		p.requiredUnless = &RequiredUnless{FieldPath: field, Value: value}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func stringRule(specs []ruleSpec) (String, error) {
+	var presence presenceSpec
+	var rule String
+	for _, spec := range specs {
+		if ok, err := presence.apply(spec); ok {
+			if err != nil {
+				return rule, spec.err(err)
+			}
+			continue
+		}
+		var err error
+		switch spec.name {
+		case "min":
+			rule.Min, err = spec.int()
+		case "max":
+			rule.Max, err = spec.int()
+		case "regex":
+			rule.Regex = spec.arg
+		case "not_regex":
+			rule.NotRegex = spec.arg
+		case "in":
+			rule.In = spec.list()
+		case "not_in":
+			rule.NotIn = spec.list()
+		case "email":
+			rule.Email = true
+		case "alpha":
+			rule.Alpha = true
+		case "alpha_num", "alpha_numeric":
+			rule.AlphaNumeric = true
+		case "trim", "lowercase", "uppercase", "collapse_spaces", "strip_tags", "nfc", "nfkc":
+			rule.Transform = append(rule.Transform, transformers[spec.name])
+		case "url":
+			rule.Url = &Url{}
+			for _, scheme := range spec.list() {
+				switch scheme {
+				case urlHttp:
+					rule.Url.Http = true
+				case urlHttps:
+					rule.Url.Https = true
+				default:
+					err = fmt.Errorf("unknown url scheme %q", scheme)
+				}
+			}
+		default:
+			err = unknownRule(spec.name)
+		}
+		if err != nil {
+			return rule, spec.err(err)
+		}
+	}
+	rule.Required = presence.required
+	rule.RequiredIf = presence.requiredIf
+	rule.RequiredUnless = presence.requiredUnless
+	return rule, nil
+}
+
+func numericRule[NT NumericValue](specs []ruleSpec) (Numeric[NT], error) {
+	var presence presenceSpec
+	var rule Numeric[NT]
+	for _, spec := range specs {
+		if ok, err := presence.apply(spec); ok {
+			if err != nil {
+				return rule, spec.err(err)
+			}
+			continue
+		}
+		var err error
+		switch spec.name {
+		case "min":
+			rule.Min, err = spec.int()
+		case "max":
+			rule.Max, err = spec.int()
+		case "min_digits":
+			rule.MinDigits, err = spec.int()
+		case "max_digits":
+			rule.MaxDigits, err = spec.int()
+		case "regex":
+			rule.Regex = spec.arg
+		case "not_regex":
+			rule.NotRegex = spec.arg
+		case "in":
+			rule.In, err = parseNumericList[NT](spec.list())
+		case "not_in":
+			rule.NotIn, err = parseNumericList[NT](spec.list())
+		default:
+			err = unknownRule(spec.name)
+		}
+		if err != nil {
+			return rule, spec.err(err)
+		}
+	}
+	rule.Required = presence.required
+	rule.RequiredIf = presence.requiredIf
+	rule.RequiredUnless = presence.requiredUnless
+	return rule, nil
+}
+
+func booleanRule(specs []ruleSpec) (Boolean, error) {
+	var presence presenceSpec
+	var rule Boolean
+	for _, spec := range specs {
+		ok, err := presence.apply(spec)
+		if !ok {
+			err = unknownRule(spec.name)
+		}
+		if err != nil {
+			return rule, spec.err(err)
+		}
+	}
+	rule.Required = presence.required
+	rule.RequiredIf = presence.requiredIf
+	rule.RequiredUnless = presence.requiredUnless
+	return rule, nil
+}
+
+func sliceRule[T SliceValueType](specs []ruleSpec) (Slice[T], error) {
+	var presence presenceSpec
+	var rule Slice[T]
+	for _, spec := range specs {
+		if ok, err := presence.apply(spec); ok {
+			if err != nil {
+				return rule, spec.err(err)
+			}
+			continue
+		}
+		var err error
+		switch spec.name {
+		case "min":
+			rule.Min, err = spec.int()
+		case "max":
+			rule.Max, err = spec.int()
+		default:
+			err = unknownRule(spec.name)
+		}
+		if err != nil {
+			return rule, spec.err(err)
+		}
+	}
+	rule.Required = presence.required
+	rule.RequiredIf = presence.requiredIf
+	rule.RequiredUnless = presence.requiredUnless
+	return rule, nil
+}
+
+func objectRule(specs []ruleSpec, item SchemaObject) (Object, error) {
+	var presence presenceSpec
+	rule := Object{Item: item}
+	for _, spec := range specs {
+		ok, err := presence.apply(spec)
+		if !ok {
+			err = unknownRule(spec.name)
+		}
+		if err != nil {
+			return rule, spec.err(err)
+		}
+	}
+	rule.Required = presence.required
+	rule.RequiredIf = presence.requiredIf
+	rule.RequiredUnless = presence.requiredUnless
+	return rule, nil
+}
+
+func sliceObjectRule(specs []ruleSpec, item SchemaObject) (SliceObject, error) {
+	var presence presenceSpec
+	rule := SliceObject{Item: item}
+	for _, spec := range specs {
+		if ok, err := presence.apply(spec); ok {
+			if err != nil {
+				return rule, spec.err(err)
+			}
+			continue
+		}
+		var err error
+		switch spec.name {
+		case "min":
+			rule.Min, err = spec.int()
+		case "max":
+			rule.Max, err = spec.int()
+		default:
+			err = unknownRule(spec.name)
+		}
+		if err != nil {
+			return rule, spec.err(err)
+		}
+	}
+	rule.Required = presence.required
+	rule.RequiredIf = presence.requiredIf
+	rule.RequiredUnless = presence.requiredUnless
+	return rule, nil
+}
+
+func unknownRule(name string) error {
+	if knownRules[name] {
+		return errUnsupportedRule
+	}
+	return errUnknownRule
+}
+
+var knownRules = map[string]bool{
+	"required": true, "required_if": true, "required_unless": true,
+	"min": true, "max": true, "min_digits": true, "max_digits": true,
+	"regex": true, "not_regex": true, "in": true, "not_in": true,
+	"email": true, "alpha": true, "alpha_num": true, "alpha_numeric": true, "url": true,
+	"trim": true, "lowercase": true, "uppercase": true, "collapse_spaces": true, "strip_tags": true,
+	"nfc": true, "nfkc": true,
+}
+
+func parseNumericList[NT NumericValue](values []string) ([]NT, error) {
+	var parsed []NT
+	for _, v := range values {
+		n, err := parseNumeric[NT](v)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, n)
+	}
+	return parsed, nil
+}
+
+func parseNumeric[NT NumericValue](value string) (NT, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err == nil {
+		switch any(*new(NT)).(type) {
+		case float32, float64:
+			return NT(f), nil
+		}
+	}
+	if err != nil || float64(NT(f)) != f {
+		return 0, fmt.Errorf("invalid %T value %q", *new(NT), value)
+	}
+	return NT(f), nil
+}
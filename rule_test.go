@@ -0,0 +1,94 @@
+package validet_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ezartsh/validet"
+)
+
+type Money struct {
+	Required bool
+	Currency []string
+}
+
+func (m Money) IsMyTypeOf(schema any) bool {
+	return reflect.TypeOf(schema) == reflect.TypeOf(Money{})
+}
+
+func (m Money) Process(ctx validet.RuleContext) ([]validet.FieldError, error) {
+	return m.Validate(ctx.OriginalData, ctx.Value(), ctx)
+}
+
+func (m Money) Validate(source []byte, value any, ctx validet.RuleContext) ([]validet.FieldError, error) {
+	if value == nil {
+		if m.Required {
+			return []validet.FieldError{{
+				Rule:    validet.RuleRequired,
+				Message: fmt.Sprintf("%s is required", ctx.Key),
+			}}, errors.New("money validation failed")
+		}
+		return nil, nil
+	}
+	amount, ok := value.(validet.DataObject)
+	if !ok {
+		return []validet.FieldError{{
+			Rule:    validet.RuleType,
+			Params:  map[string]any{"type": "money"},
+			Value:   value,
+			Message: fmt.Sprintf("%s must be a money object", ctx.Key),
+		}}, errors.New("money validation failed")
+	}
+	currency, _ := amount["currency"].(string)
+	for _, c := range m.Currency {
+		if c == currency {
+			return nil, nil
+		}
+	}
+	return []validet.FieldError{{
+		Rule:    "currency",
+		Params:  map[string]any{"currency": m.Currency},
+		Value:   value,
+		Message: fmt.Sprintf("%s has an unsupported currency", ctx.Key),
+	}}, errors.New("money validation failed")
+}
+
+func Test_Custom_Rule_Outside_Package(t *testing.T) {
+	rules := validet.SchemaRules{
+		"price": Money{Required: true, Currency: []string{"IDR", "USD"}},
+		"order": validet.Object{
+			Required: true,
+			Item: validet.SchemaObject{
+				"total": Money{Required: true, Currency: []string{"IDR"}},
+			},
+		},
+	}
+
+	t.Run("it should pass when the custom rule accepts the values", func(t *testing.T) {
+		schema := validet.NewSchema(validet.DataObject{
+			"price": validet.DataObject{"amount": 10.0, "currency": "USD"},
+			"order": validet.DataObject{
+				"total": validet.DataObject{"amount": 10.0, "currency": "IDR"},
+			},
+		}, rules, validet.Options{})
+		if bags, err := schema.Validate(); err != nil {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, nil)
+		}
+	})
+	t.Run("it should report the custom rule errors under nested keys", func(t *testing.T) {
+		schema := validet.NewSchema(validet.DataObject{
+			"order": validet.DataObject{
+				"total": validet.DataObject{"amount": 10.0, "currency": "USD"},
+			},
+		}, rules, validet.Options{})
+		bags, _ := schema.Validate()
+		if _, ok := bags.Errors["price"]; !ok {
+			t.Errorf("Actual = %v, Expected key = price", bags.Errors)
+		}
+		if fields := bags.Fields["order.total"]; len(fields) != 1 || fields[0].Rule != "currency" || fields[0].Key() != "order.total" {
+			t.Errorf("Actual = %v, Expected a currency error on order.total", bags.Fields)
+		}
+	})
+}
@@ -1,6 +1,20 @@
 package validet
 
-type RuleParams struct {
+import (
+	"sort"
+
+	"github.com/tidwall/gjson"
+)
+
+// RuleContext carries everything a Rule needs to validate a single field:
+// the original JSON source, the parent data holding the field, the path of
+// the parent, the field key, the rule itself and the validation options.
+//
+// Output is the validated copy of the parent object when one is being
+// collected (see Bind) and nil otherwise. The field value is copied into it
+// before Process runs; rules holding nested rules replace it with the
+// validated copy of their children.
+type RuleContext struct {
 	OriginalData []byte
 	DataKey      any
 	PathKey      []string
@@ -8,20 +22,76 @@ type RuleParams struct {
 	Schema       Rule
 	ErrorBags    *ErrorBag
 	Option       Options
+	Output       DataObject
+}
+
+// RuleParams is the former name of RuleContext.
+//
+// Deprecated: use RuleContext.
+type RuleParams = RuleContext
+
+// Value returns the raw value of the field being validated, or nil when the
+// field is absent. DataKey is either a DataObject or, when validating raw
+// JSON, the gjson.Result of the parent object.
+func (c RuleContext) Value() any {
+	switch data := c.DataKey.(type) {
+	case DataObject:
+		return data[c.Key]
+	case gjson.Result:
+		return jsonValue(data.Get(gjson.Escape(c.Key)))
+	}
+	return nil
+}
+
+// Path returns the location of the field being validated.
+func (c RuleContext) Path() PathKey {
+	return PathKey{
+		Previous: c.PathKey,
+		Current:  c.Key,
+	}
 }
 
+// Lookup queries the original data with a gjson path.
+func (c RuleContext) Lookup(path string) gjson.Result {
+	return gjson.GetBytes(c.OriginalData, path)
+}
+
+// Rule is the contract every schema rule fulfils. Rule values can be placed
+// in SchemaRules, Object.Item and SliceObject.Item.
+//
+// Process is called once per field with the context of that field. It
+// usually resolves the field value and delegates to Validate. Validate
+// checks a single value and returns a FieldError for every failed check; a
+// non-nil error marks the field as failed. The Path of the returned errors
+// may be left empty, it is filled with the field path. IsMyTypeOf reports
+// whether the given schema value is handled by this rule type.
 type Rule interface {
-	validate(source []byte, value any, params RuleParams) ([]string, error)
-	isMyTypeOf(schema any) bool
-	process(RuleParams) ([]string, error)
+	Validate(source []byte, value any, ctx RuleContext) ([]FieldError, error)
+	IsMyTypeOf(schema any) bool
+	Process(ctx RuleContext) ([]FieldError, error)
 }
 
+// SchemaRules is evaluated in the lexical order of its keys. Use
+// OrderedRules to evaluate the fields in declaration order.
 type SchemaRules = map[string]Rule
 
+// Field is a single named rule of an OrderedRules schema.
+type Field struct {
+	Key  string
+	Rule Rule
+}
+
+// OrderedRules is a schema whose fields are evaluated, and reported in
+// ErrorBag.Keys, in declaration order.
+type OrderedRules []Field
+
 type SchemaContainer struct {
 	Data    DataObject
 	Items   SchemaRules
+	Fields  OrderedRules
 	Options Options
+
+	validated DataObject
 }
 
 func NewSchema(d DataObject, items SchemaRules, options Options) SchemaContainer {
@@ -32,6 +102,62 @@ func NewSchema(d DataObject, items SchemaRules, options Options) SchemaContainer
 	}
 }
 
+func NewOrderedSchema(d DataObject, fields OrderedRules, options Options) SchemaContainer {
+	return SchemaContainer{
+		Data:    d,
+		Fields:  fields,
+		Options: options,
+	}
+}
+
 func (s *SchemaContainer) Validate() (ErrorBag, error) {
-	return validate(s.Data, s.Items, s.Options)
+	validation := Validation{
+		data:    s.Data,
+		schema:  s.Items,
+		options: s.Options,
+	}
+	if s.Fields != nil {
+		validation.schema = s.Fields
+	}
+	validated, bags, err := runValidated(&validation)
+	s.validated = validated
+	return bags, err
+}
+
+// Validated returns the validated values of the last successful Validate,
+// like the data returned by Bind: the declared fields only, with their
+// transformed, defaulted and coerced values. It is nil before Validate and
+// after a failed one.
+func (s *SchemaContainer) Validated() DataObject {
+	return s.validated
+}
+
+func sortedFields(rules SchemaRules) OrderedRules {
+	fields := make(OrderedRules, 0, len(rules))
+	for key, rule := range rules {
+		fields = append(fields, Field{Key: key, Rule: rule})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Key < fields[j].Key
+	})
+	return fields
+}
+
+// itemFields merges the ordered fields of an Object or SliceObject with the
+// rules of its Item, which follow in the lexical order of their keys.
+func itemFields(fields OrderedRules, item SchemaObject) OrderedRules {
+	if len(item) == 0 {
+		return fields
+	}
+	declared := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		declared[field.Key] = true
+	}
+	rules := SchemaRules{}
+	for key, value := range item {
+		if rule, ok := isRule(value); ok && !declared[key] {
+			rules[key] = rule
+		}
+	}
+	return append(fields[:len(fields):len(fields)], sortedFields(rules)...)
 }
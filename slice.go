@@ -27,10 +27,11 @@ type Slice[T SliceValueType] struct {
 	Min            int
 	Max            int
 	Custom         func(v []T, path PathKey, look Lookup) error
+	Default        any
 	Message        SliceErrorMessage
 }
 
-func (s Slice[T]) isMyTypeOf(schema any) bool {
+func (s Slice[T]) IsMyTypeOf(schema any) bool {
 	return reflect.TypeOf(schema).Kind() == reflect.Struct && (reflect.TypeOf(schema) == reflect.TypeOf(Slice[int]{}) ||
 		reflect.TypeOf(schema) == reflect.TypeOf(Slice[int32]{}) ||
 		reflect.TypeOf(schema) == reflect.TypeOf(Slice[int64]{}) ||
@@ -42,70 +43,32 @@ func (s Slice[T]) isMyTypeOf(schema any) bool {
 		reflect.TypeOf(schema) == reflect.TypeOf(Slice[string]{}))
 }
 
-func (s Slice[T]) process(params RuleParams) ([]string, error) {
-	schemaData := params.DataKey.(DataObject)
-	var err error
-	var bags []string
+func (s Slice[T]) Process(params RuleContext) ([]FieldError, error) {
+	return s.Validate(params.OriginalData, params.Value(), params)
+}
 
-	schema := params.Schema
-	originalData := params.OriginalData
+func (s Slice[T]) Validate(jsonSource []byte, value any, params RuleContext) ([]FieldError, error) {
+	var bags []FieldError
 	key := params.Key
+	option := params.Option
 
-	switch reflect.TypeOf(schema) {
-	case reflect.TypeOf(Slice[string]{}):
-		if scMap, ok := schema.(Slice[string]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Slice[int]{}):
-		if scMap, ok := schema.(Slice[int]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Slice[int32]{}):
-		if scMap, ok := schema.(Slice[int32]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Slice[int64]{}):
-		if scMap, ok := schema.(Slice[int64]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Slice[uint]{}):
-		if scMap, ok := schema.(Slice[uint]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Slice[uint32]{}):
-		if scMap, ok := schema.(Slice[uint32]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Slice[uint64]{}):
-		if scMap, ok := schema.(Slice[uint64]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Slice[float32]{}):
-		if scMap, ok := schema.(Slice[float32]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
-		}
-	case reflect.TypeOf(Slice[float64]{}):
-		if scMap, ok := schema.(Slice[float64]); ok {
-			bags, err = scMap.validate(originalData, schemaData[key], params)
+	if option.Coerce {
+		coerced, ok := s.coerce(value)
+		if !ok {
+			appendErrorBags(
+				&bags,
+				key,
+				FieldError{Rule: RuleType, Params: map[string]any{"type": fmt.Sprintf("%T", *new(T))}, Value: value},
+				"type.coerce",
+				"",
+			)
+			return bags, SliceValidationError
 		}
+		value = coerced
+		setCoerced(params, value)
 	}
 
-	return bags, err
-
-	// pathKey := params.PathKey + key
-	// if err != nil {
-	// 	params.ErrorBags.append(pathKey, bags)
-	// 	if options.AbortEarly {
-	// 		return errors.New("error")
-	// 	}
-	// }
-	// return []string{}, nil
-}
-
-func (s Slice[T]) validate(jsonSource []byte, value any, params RuleParams) ([]string, error) {
-	var bags []string
-	key := params.Key
-	option := params.Option
+	value = applyDefault(s.Default, value, params)
 
 	err := s.assertRequired(key, value, &bags)
 
@@ -122,7 +85,18 @@ func (s Slice[T]) validate(jsonSource []byte, value any, params RuleParams) ([]s
 	}
 
 	if value != nil {
-		values := value.([]any)
+		values, ok := value.([]any)
+
+		if !ok {
+			appendErrorBags(
+				&bags,
+				key,
+				FieldError{Rule: RuleType, Params: map[string]any{"type": fmt.Sprintf("%T", *new(T))}, Value: value},
+				"type.slice",
+				"",
+			)
+			return bags, SliceValidationError
+		}
 
 		if len(values) > 0 {
 			parsedValue, err := s.assertType(key, values, &bags)
@@ -159,7 +133,23 @@ func (s Slice[T]) validate(jsonSource []byte, value any, params RuleParams) ([]s
 
 }
 
-func (s Slice[T]) assertType(key string, values []any, bags *[]string) ([]T, error) {
+// coerce converts the items of the slice into a copy for Options.Coerce.
+func (s Slice[T]) coerce(value any) (any, bool) {
+	values, ok := value.([]any)
+	if !ok {
+		return value, true
+	}
+	t := reflect.TypeOf(*new(T))
+	coerced := make([]any, len(values))
+	for i, item := range values {
+		if coerced[i], ok = coerceNumber(item, t); !ok {
+			return value, false
+		}
+	}
+	return coerced, true
+}
+
+func (s Slice[T]) assertType(key string, values []any, bags *[]FieldError) ([]T, error) {
 	failed := false
 	var parsedValues []T
 	for _, value := range values {
@@ -172,7 +162,9 @@ func (s Slice[T]) assertType(key string, values []any, bags *[]string) ([]T, err
 	if failed {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be slice of type %T", key, *new(T)),
+			key,
+			FieldError{Rule: RuleType, Params: map[string]any{"type": fmt.Sprintf("%T", *new(T))}, Value: values},
+			"type.slice",
 			"",
 		)
 		return []T{}, SliceValidationError
@@ -181,12 +173,14 @@ func (s Slice[T]) assertType(key string, values []any, bags *[]string) ([]T, err
 	return parsedValues, nil
 }
 
-func (s Slice[T]) assertRequired(key string, value any, bags *[]string) error {
+func (s Slice[T]) assertRequired(key string, value any, bags *[]FieldError) error {
 	if s.Required {
 		if value == nil {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return SliceValidationError
@@ -195,7 +189,9 @@ func (s Slice[T]) assertRequired(key string, value any, bags *[]string) error {
 			if len(values) == 0 {
 				appendErrorBags(
 					bags,
-					fmt.Sprintf("%s is required", key),
+					key,
+					FieldError{Rule: RuleRequired, Value: value},
+					"required",
 					s.Message.Required,
 				)
 				return SliceValidationError
@@ -203,7 +199,9 @@ func (s Slice[T]) assertRequired(key string, value any, bags *[]string) error {
 		} else {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s must be slice of type %T", key, *new(T)),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return SliceValidationError
@@ -212,14 +210,15 @@ func (s Slice[T]) assertRequired(key string, value any, bags *[]string) error {
 	return nil
 }
 
-func (s Slice[T]) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]string) error {
-	values := value.([]any)
+func (s Slice[T]) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]FieldError) error {
+	values, _ := value.([]any)
 	if s.RequiredIf != nil && (value == nil || len(values) == 0) {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredIf.FieldPath)
-		if comparedValue.String() == s.RequiredIf.Value {
+		if s.RequiredIf.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+				"required_if",
 				s.Message.RequiredIf,
 			)
 			return SliceValidationError
@@ -228,14 +227,15 @@ func (s Slice[T]) assertRequiredIf(jsonSource []byte, key string, value any, bag
 	return nil
 }
 
-func (s Slice[T]) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]string) error {
-	values := value.([]any)
+func (s Slice[T]) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]FieldError) error {
+	values, _ := value.([]any)
 	if s.RequiredUnless != nil && (value == nil || len(values) == 0) {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredUnless.FieldPath)
-		if comparedValue.String() != s.RequiredUnless.Value {
+		if !s.RequiredUnless.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+				"required_unless",
 				s.Message.RequiredUnless,
 			)
 			return SliceValidationError
@@ -244,11 +244,13 @@ func (s Slice[T]) assertRequiredUnless(jsonSource []byte, key string, value any,
 	return nil
 }
 
-func (s Slice[T]) assertMin(key string, values []T, bags *[]string) error {
+func (s Slice[T]) assertMin(key string, values []T, bags *[]FieldError) error {
 	if s.Min > 0 && len(values) < s.Min {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be minimum of %d", key, s.Min),
+			key,
+			FieldError{Rule: RuleMin, Params: map[string]any{"min": s.Min}, Value: values},
+			"min.slice",
 			s.Message.Min,
 		)
 		return SliceValidationError
@@ -256,11 +258,13 @@ func (s Slice[T]) assertMin(key string, values []T, bags *[]string) error {
 	return nil
 }
 
-func (s Slice[T]) assertMax(key string, values []T, bags *[]string) error {
+func (s Slice[T]) assertMax(key string, values []T, bags *[]FieldError) error {
 	if s.Max > 0 && len(values) > s.Max {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be maximum of %d", key, s.Max),
+			key,
+			FieldError{Rule: RuleMax, Params: map[string]any{"max": s.Max}, Value: values},
+			"max.slice",
 			s.Message.Max,
 		)
 		return SliceValidationError
@@ -268,14 +272,17 @@ func (s Slice[T]) assertMax(key string, values []T, bags *[]string) error {
 	return nil
 }
 
-func (s Slice[T]) assertCustomValidation(fc func(v []T, path PathKey, look Lookup) error, jsonSource []byte, value []T, path PathKey, bags *[]string) error {
+func (s Slice[T]) assertCustomValidation(fc func(v []T, path PathKey, look Lookup) error, jsonSource []byte, value []T, path PathKey, bags *[]FieldError) error {
 	err := fc(value, path, func(k string) gjson.Result {
 		return gjson.GetBytes(jsonSource, k)
 	})
 	if err != nil {
+		fe, messageKey := customFieldError(err, value)
 		appendErrorBags(
 			bags,
-			err.Error(),
+			path.Current,
+			fe,
+			messageKey,
 			s.Message.Custom,
 		)
 		return SliceValidationError
@@ -1,7 +1,6 @@
 package validet
 
 import (
-	"fmt"
 	"reflect"
 	"strconv"
 
@@ -17,6 +16,9 @@ type SliceObjectErrorMessage struct {
 	Custom         string
 }
 
+// SliceObject validates a list of objects with the rules of Item, or of
+// Fields to keep their order. Like for Object, only these fields of every
+// item are collected by Bind and Validated.
 type SliceObject struct {
 	Required       bool
 	RequiredIf     *RequiredIf
@@ -24,27 +26,29 @@ type SliceObject struct {
 	Min            int
 	Max            int
 	Item           DataObject
+	Fields         OrderedRules
 	Custom         func(v []DataObject, path PathKey, look Lookup) error
+	Default        any
 	Message        SliceObjectErrorMessage
 }
 
-func (s SliceObject) isMyTypeOf(schema any) bool {
+func (s SliceObject) IsMyTypeOf(schema any) bool {
 	return reflect.TypeOf(schema).Kind() == reflect.Struct && reflect.TypeOf(schema) == reflect.TypeOf(SliceObject{})
 }
 
-func (s SliceObject) process(params RuleParams) ([]string, error) {
-	schemaData := params.DataKey.(DataObject)
+func (s SliceObject) Process(params RuleContext) ([]FieldError, error) {
+	value := applyDefault(s.Default, params.Value(), params)
 	// var err error
-	// var bags []string
+	// var bags []FieldError
 
-	errorBags := *params.ErrorBags
+	errorBags := params.ErrorBags
 	schema := params.Schema
 	originalData := params.OriginalData
 	key := params.Key
 	options := params.Option
 
 	if scSliceObject, ok := schema.(SliceObject); ok {
-		bags, err := scSliceObject.validate(originalData, schemaData[key], params)
+		bags, err := scSliceObject.Validate(originalData, value, params)
 		if err != nil {
 			return bags, err
 			// errorBags.append(params.PathKey+key, bags)
@@ -52,14 +56,26 @@ func (s SliceObject) process(params RuleParams) ([]string, error) {
 			// 	return errors.New("new error")
 			// }
 		} else {
-			schemaDataValues := schemaData[key].([]interface{})
-			for i, value := range schemaDataValues {
-				for scObjItemKey, scObjItemValue := range scSliceObject.Item {
+			schemaDataValues, _ := value.([]interface{})
+			fields := itemFields(scSliceObject.Fields, scSliceObject.Item)
+			var outputs []any
+			if params.Output != nil && schemaDataValues != nil {
+				outputs = make([]any, len(schemaDataValues))
+				params.Output[key] = outputs
+			}
+		items:
+			for i, itemValue := range schemaDataValues {
+				var output DataObject
+				if outputs != nil {
+					output = DataObject{}
+					outputs[i] = output
+				}
+				for _, field := range fields {
 					path := append(params.PathKey, key)
-					mapSchemas(originalData, append(path, strconv.Itoa(i)), scObjItemKey, value, scObjItemValue, &errorBags, options)
-					// if options.AbortEarly && len(errorBags.Errors) > 0 {
-					// 	return errors.New("new error")
-					// }
+					mapSchemas(originalData, append(path, strconv.Itoa(i)), field.Key, itemValue, field.Rule, errorBags, options, output)
+					if options.AbortEarly && len(errorBags.Errors) > 0 {
+						break items
+					}
 				}
 			}
 		}
@@ -72,14 +88,16 @@ func (s SliceObject) process(params RuleParams) ([]string, error) {
 	// 		return errors.New("error")
 	// 	}
 	// }
-	return []string{}, nil
+	return nil, nil
 }
 
-func (s SliceObject) validate(jsonSource []byte, value any, params RuleParams) ([]string, error) {
-	var bags []string
+func (s SliceObject) Validate(jsonSource []byte, value any, params RuleContext) ([]FieldError, error) {
+	var bags []FieldError
 	key := params.Key
 	option := params.Option
 
+	value = applyDefault(s.Default, value, params)
+
 	err := s.assertRequired(key, value, &bags)
 
 	if err != nil {
@@ -137,12 +155,14 @@ func (s SliceObject) validate(jsonSource []byte, value any, params RuleParams) (
 
 }
 
-func (s SliceObject) assertRequired(key string, value any, bags *[]string) error {
+func (s SliceObject) assertRequired(key string, value any, bags *[]FieldError) error {
 	if s.Required {
 		if value == nil {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return SliceObjectValidationError
@@ -154,7 +174,9 @@ func (s SliceObject) assertRequired(key string, value any, bags *[]string) error
 		if len(values) == 0 {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return SliceObjectValidationError
@@ -163,7 +185,7 @@ func (s SliceObject) assertRequired(key string, value any, bags *[]string) error
 	return nil
 }
 
-func (s SliceObject) assertType(key string, value any, bags *[]string) ([]DataObject, error) {
+func (s SliceObject) assertType(key string, value any, bags *[]FieldError) ([]DataObject, error) {
 	if values, ok := value.([]interface{}); ok {
 		sliceDataObject := []DataObject{}
 		for _, v := range values {
@@ -177,20 +199,23 @@ func (s SliceObject) assertType(key string, value any, bags *[]string) ([]DataOb
 	}
 	appendErrorBags(
 		bags,
-		fmt.Sprintf("%s must be type of data object", key),
+		key,
+		FieldError{Rule: RuleType, Params: map[string]any{"type": "slice of object"}, Value: value},
+		"type.slice_object",
 		"",
 	)
 	return []DataObject{}, SliceObjectValidationError
 }
 
-func (s SliceObject) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]string) error {
-	values := value.([]interface{})
+func (s SliceObject) assertRequiredIf(jsonSource []byte, key string, value any, bags *[]FieldError) error {
+	values, _ := value.([]interface{})
 	if s.RequiredIf != nil && (value == nil || len(values) == 0) {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredIf.FieldPath)
-		if comparedValue.String() == s.RequiredIf.Value {
+		if s.RequiredIf.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+				"required_if",
 				s.Message.RequiredIf,
 			)
 			return SliceObjectValidationError
@@ -199,14 +224,15 @@ func (s SliceObject) assertRequiredIf(jsonSource []byte, key string, value any,
 	return nil
 }
 
-func (s SliceObject) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]string) error {
-	values := value.([]interface{})
+func (s SliceObject) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]FieldError) error {
+	values, _ := value.([]interface{})
 	if s.RequiredUnless != nil && (value == nil || len(values) == 0) {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredUnless.FieldPath)
-		if comparedValue.String() != s.RequiredUnless.Value {
+		if !s.RequiredUnless.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+				"required_unless",
 				s.Message.RequiredUnless,
 			)
 			return SliceObjectValidationError
@@ -215,11 +241,13 @@ func (s SliceObject) assertRequiredUnless(jsonSource []byte, key string, value a
 	return nil
 }
 
-func (s SliceObject) assertMin(key string, values []DataObject, bags *[]string) error {
+func (s SliceObject) assertMin(key string, values []DataObject, bags *[]FieldError) error {
 	if s.Min > 0 && len(values) < s.Min {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be minimum of %d", key, s.Min),
+			key,
+			FieldError{Rule: RuleMin, Params: map[string]any{"min": s.Min}, Value: values},
+			"min.slice_object",
 			s.Message.Min,
 		)
 		return SliceObjectValidationError
@@ -227,11 +255,13 @@ func (s SliceObject) assertMin(key string, values []DataObject, bags *[]string)
 	return nil
 }
 
-func (s SliceObject) assertMax(key string, values []DataObject, bags *[]string) error {
+func (s SliceObject) assertMax(key string, values []DataObject, bags *[]FieldError) error {
 	if s.Max > 0 && len(values) > s.Max {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be maximum of %d", key, s.Max),
+			key,
+			FieldError{Rule: RuleMax, Params: map[string]any{"max": s.Max}, Value: values},
+			"max.slice_object",
 			s.Message.Max,
 		)
 		return SliceObjectValidationError
@@ -239,14 +269,17 @@ func (s SliceObject) assertMax(key string, values []DataObject, bags *[]string)
 	return nil
 }
 
-func (s SliceObject) assertCustomValidation(fc func(v []DataObject, path PathKey, look Lookup) error, jsonSource []byte, value []DataObject, path PathKey, bags *[]string) error {
+func (s SliceObject) assertCustomValidation(fc func(v []DataObject, path PathKey, look Lookup) error, jsonSource []byte, value []DataObject, path PathKey, bags *[]FieldError) error {
 	err := fc(value, path, func(k string) gjson.Result {
 		return gjson.GetBytes(jsonSource, k)
 	})
 	if err != nil {
+		fe, messageKey := customFieldError(err, value)
 		appendErrorBags(
 			bags,
-			err.Error(),
+			path.Current,
+			fe,
+			messageKey,
 			s.Message.Custom,
 		)
 		return SliceObjectValidationError
@@ -1,7 +1,6 @@
 package validet
 
 import (
-	"fmt"
 	"reflect"
 	"regexp"
 	"slices"
@@ -42,7 +41,13 @@ type String struct {
 	AlphaNumeric   bool
 	Url            *Url
 	Custom         func(v string, path PathKey, look Lookup) error
+	Transform      []Transformer
+	Default        any
 	Message        StringErrorMessage
+
+	// regex and notRegex are compiled by Compile, see ruleRegex.
+	regex    *regexp.Regexp
+	notRegex *regexp.Regexp
 }
 
 type Url struct {
@@ -55,14 +60,44 @@ const (
 	urlHttps        = "https"
 )
 
-func (s String) isMyTypeOf(schema any) bool {
+var (
+	emailRegex        = regexp.MustCompile(`^([a-zA-Z0-9._%-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,})$`)
+	alphaRegex        = regexp.MustCompile(`^[a-zA-Z]+$`)
+	alphaNumericRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+	urlRegexes        = map[Url]*regexp.Regexp{
+		{}:                        regexp.MustCompile(Url{}.expression()),
+		{Http: true}:              regexp.MustCompile(Url{Http: true}.expression()),
+		{Https: true}:             regexp.MustCompile(Url{Https: true}.expression()),
+		{Http: true, Https: true}: regexp.MustCompile(Url{Http: true, Https: true}.expression()),
+	}
+)
+
+func (u Url) schemes() []string {
+	var prefix []string
+	if u.Http {
+		prefix = append(prefix, urlHttp)
+	}
+	if u.Https {
+		prefix = append(prefix, urlHttps)
+	}
+	if len(prefix) == 0 {
+		prefix = []string{"http", "https"}
+	}
+	return prefix
+}
+
+func (u Url) expression() string {
+	return `^((` + strings.Join(u.schemes(), "|") + `):\/\/)[-a-zA-Z0-9@:%._\\+~#?&\/=]{2,256}\.[a-z]{2,6}\b([-a-zA-Z0-9@:%._\\+~#?&\/=]*)$`
+}
+
+func (s String) IsMyTypeOf(schema any) bool {
 	return reflect.TypeOf(schema).Kind() == reflect.Struct && reflect.TypeOf(schema) == reflect.TypeOf(String{})
 }
 
-func (s String) process(params RuleParams) ([]string, error) {
+func (s String) Process(params RuleContext) ([]FieldError, error) {
 	// errorBags := params.ErrorBags
-	schemaData := params.DataKey.(DataObject)
-	return params.Schema.validate(params.OriginalData, schemaData[params.Key], params)
+	value := params.Value()
+	return params.Schema.Validate(params.OriginalData, value, params)
 	// pathKey := params.PathKey + params.Key
 	// if err != nil {
 	// 	errorBags.append(pathKey, bags)
@@ -73,11 +108,20 @@ func (s String) process(params RuleParams) ([]string, error) {
 	// return nil
 }
 
-func (s String) validate(source []byte, value any, params RuleParams) ([]string, error) {
-	var bags []string
+func (s String) Validate(source []byte, value any, params RuleContext) ([]FieldError, error) {
+	var bags []FieldError
 	key := params.Key
 	option := params.Option
 
+	value = applyDefault(s.Default, value, params)
+
+	if text, ok := value.(string); ok && len(s.Transform) > 0 {
+		value = s.transform(text)
+		if params.Output != nil {
+			params.Output[key] = value
+		}
+	}
+
 	err := s.assertRequired(key, value, &bags)
 
 	if err != nil {
@@ -158,14 +202,16 @@ func (s String) validate(source []byte, value any, params RuleParams) ([]string,
 	return bags, nil
 }
 
-func (s String) assertType(key string, value any, bags *[]string) (string, error) {
+func (s String) assertType(key string, value any, bags *[]FieldError) (string, error) {
 	var stringValue string
 	if isStringValue(value) {
 		stringValue = value.(string)
 	} else {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must be type of string", key),
+			key,
+			FieldError{Rule: RuleType, Params: map[string]any{"type": "string"}, Value: value},
+			"type.string",
 			"",
 		)
 		return "", StringValidationError
@@ -173,12 +219,14 @@ func (s String) assertType(key string, value any, bags *[]string) (string, error
 	return stringValue, nil
 }
 
-func (s String) assertRequired(key string, value any, bags *[]string) error {
+func (s String) assertRequired(key string, value any, bags *[]FieldError) error {
 	if s.Required {
 		if value == nil {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequired, Value: value},
+				"required",
 				s.Message.Required,
 			)
 			return StringValidationError
@@ -187,7 +235,9 @@ func (s String) assertRequired(key string, value any, bags *[]string) error {
 			if stringLength(value) == 0 {
 				appendErrorBags(
 					bags,
-					fmt.Sprintf("%s is required", key),
+					key,
+					FieldError{Rule: RuleRequired, Value: value},
+					"required",
 					s.Message.Required,
 				)
 				return StringValidationError
@@ -197,13 +247,14 @@ func (s String) assertRequired(key string, value any, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertRequiredIf(jsonSource []byte, key string, value any, params RuleParams, bags *[]string) error {
+func (s String) assertRequiredIf(jsonSource []byte, key string, value any, params RuleContext, bags *[]FieldError) error {
 	if s.RequiredIf != nil && (value == nil || (isStringValue(value) && stringLength(value) == 0)) {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredIf.FieldPath)
-		if comparedValue.String() == s.RequiredIf.Value {
+		if s.RequiredIf.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredIf, Params: map[string]any{"other": s.RequiredIf.FieldPath, "other_value": s.RequiredIf.Value}, Value: value},
+				"required_if",
 				s.Message.RequiredIf,
 			)
 			return StringValidationError
@@ -212,13 +263,14 @@ func (s String) assertRequiredIf(jsonSource []byte, key string, value any, param
 	return nil
 }
 
-func (s String) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]string) error {
+func (s String) assertRequiredUnless(jsonSource []byte, key string, value any, bags *[]FieldError) error {
 	if s.RequiredUnless != nil && (value == nil || (isStringValue(value) && stringLength(value) == 0)) {
-		comparedValue := gjson.GetBytes(jsonSource, s.RequiredUnless.FieldPath)
-		if comparedValue.String() != s.RequiredUnless.Value {
+		if !s.RequiredUnless.matches(jsonSource) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is required", key),
+				key,
+				FieldError{Rule: RuleRequiredUnless, Params: map[string]any{"other": s.RequiredUnless.FieldPath, "other_value": s.RequiredUnless.Value}, Value: value},
+				"required_unless",
 				s.Message.RequiredUnless,
 			)
 			return StringValidationError
@@ -227,19 +279,27 @@ func (s String) assertRequiredUnless(jsonSource []byte, key string, value any, b
 	return nil
 }
 
-func (s String) assertMin(key string, value string, bags *[]string) error {
+func (s String) assertMin(key string, value string, bags *[]FieldError) error {
 	if s.Min > 0 && stringLength(value) < s.Min {
-		appendErrorBags(bags, msgf("%s must be minimum of %d character(s)", key, s.Min), s.Message.Min)
+		appendErrorBags(
+			bags,
+			key,
+			FieldError{Rule: RuleMin, Params: map[string]any{"min": s.Min}, Value: value},
+			"min.string",
+			s.Message.Min,
+		)
 		return StringValidationError
 	}
 	return nil
 }
 
-func (s String) assertMax(key string, value string, bags *[]string) error {
+func (s String) assertMax(key string, value string, bags *[]FieldError) error {
 	if s.Max > 0 && stringLength(value) > s.Max {
 		appendErrorBags(
 			bags,
-			msgf("%s must be maximum of %d character(s)", key, s.Max),
+			key,
+			FieldError{Rule: RuleMax, Params: map[string]any{"max": s.Max}, Value: value},
+			"max.string",
 			s.Message.Max,
 		)
 		return StringValidationError
@@ -247,12 +307,16 @@ func (s String) assertMax(key string, value string, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertRegex(key string, value string, bags *[]string) error {
-	regx, err := regexp.Compile(s.Regex)
-	if s.Regex != "" && stringLength(value) > 0 && (err != nil || !regx.MatchString(value)) {
+func (s String) assertRegex(key string, value string, bags *[]FieldError) error {
+	if s.Regex == "" || stringLength(value) == 0 {
+		return nil
+	}
+	if regx, err := ruleRegex(s.regex, s.Regex); err != nil || !regx.MatchString(value) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s is not a valid format", key),
+			key,
+			FieldError{Rule: RuleRegex, Params: map[string]any{"regex": s.Regex}, Value: value},
+			"regex",
 			s.Message.Regex,
 		)
 		return StringValidationError
@@ -260,12 +324,16 @@ func (s String) assertRegex(key string, value string, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertNotRegex(key string, value string, bags *[]string) error {
-	regx, err := regexp.Compile(s.Regex)
-	if s.NotRegex != "" && stringLength(value) > 0 && (err != nil || regx.MatchString(value)) {
+func (s String) assertNotRegex(key string, value string, bags *[]FieldError) error {
+	if s.NotRegex == "" || stringLength(value) == 0 {
+		return nil
+	}
+	if regx, err := ruleRegex(s.notRegex, s.NotRegex); err != nil || regx.MatchString(value) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s is not a valid format", key),
+			key,
+			FieldError{Rule: RuleNotRegex, Params: map[string]any{"not_regex": s.NotRegex}, Value: value},
+			"not_regex",
 			s.Message.NotRegex,
 		)
 		return StringValidationError
@@ -273,11 +341,13 @@ func (s String) assertNotRegex(key string, value string, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertIn(key string, value string, bags *[]string) error {
+func (s String) assertIn(key string, value string, bags *[]FieldError) error {
 	if len(s.In) > 0 && stringLength(value) > 0 && !slices.Contains(s.In, value) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must in %s", key, strings.Join(s.In, ", ")),
+			key,
+			FieldError{Rule: RuleIn, Params: map[string]any{"in": s.In}, Value: value},
+			"in",
 			s.Message.In,
 		)
 		return StringValidationError
@@ -285,11 +355,13 @@ func (s String) assertIn(key string, value string, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertNotIn(key string, value string, bags *[]string) error {
+func (s String) assertNotIn(key string, value string, bags *[]FieldError) error {
 	if len(s.NotIn) > 0 && stringLength(value) > 0 && slices.Contains(s.NotIn, value) {
 		appendErrorBags(
 			bags,
-			fmt.Sprintf("%s must not in %s", key, strings.Join(s.NotIn, ", ")),
+			key,
+			FieldError{Rule: RuleNotIn, Params: map[string]any{"not_in": s.NotIn}, Value: value},
+			"not_in",
 			s.Message.NotIn,
 		)
 		return StringValidationError
@@ -297,14 +369,15 @@ func (s String) assertNotIn(key string, value string, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertEmail(key string, value string, bags *[]string) error {
+func (s String) assertEmail(key string, value string, bags *[]FieldError) error {
 	if s.Email && stringLength(value) > 0 {
-		regx, err := regexp.Compile(`^([a-zA-Z0-9._%-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,})$`)
-		if err != nil || !regx.MatchString(value) {
+		if !emailRegex.MatchString(value) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is not a valid email", key),
-				s.Message.NotRegex,
+				key,
+				FieldError{Rule: RuleEmail, Value: value},
+				"email",
+				s.Message.Email,
 			)
 			return StringValidationError
 		}
@@ -312,14 +385,15 @@ func (s String) assertEmail(key string, value string, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertAlpha(key string, value string, bags *[]string) error {
+func (s String) assertAlpha(key string, value string, bags *[]FieldError) error {
 	if s.Alpha && stringLength(value) > 0 {
-		regx, err := regexp.Compile(`^[a-zA-Z]+$`)
-		if err != nil || !regx.MatchString(value) {
+		if !alphaRegex.MatchString(value) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is not an alphabetic value", key),
-				s.Message.NotRegex,
+				key,
+				FieldError{Rule: RuleAlpha, Value: value},
+				"alpha",
+				s.Message.Alpha,
 			)
 			return StringValidationError
 		}
@@ -327,14 +401,15 @@ func (s String) assertAlpha(key string, value string, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertAlphaNumeric(key string, value string, bags *[]string) error {
+func (s String) assertAlphaNumeric(key string, value string, bags *[]FieldError) error {
 	if s.AlphaNumeric && stringLength(value) > 0 {
-		regx, err := regexp.Compile(`^[a-zA-Z0-9]+$`)
-		if err != nil || !regx.MatchString(value) {
+		if !alphaNumericRegex.MatchString(value) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is not an alphabetic number value", key),
-				s.Message.NotRegex,
+				key,
+				FieldError{Rule: RuleAlphaNumeric, Value: value},
+				"alpha_numeric",
+				s.Message.AlphaNumeric,
 			)
 			return StringValidationError
 		}
@@ -342,24 +417,14 @@ func (s String) assertAlphaNumeric(key string, value string, bags *[]string) err
 	return nil
 }
 
-func (s String) assertUrl(key string, value string, bags *[]string) error {
+func (s String) assertUrl(key string, value string, bags *[]FieldError) error {
 	if s.Url != nil && stringLength(value) > 0 {
-		var prefix []string
-		if s.Url.Http {
-			prefix = append(prefix, urlHttp)
-		}
-		if s.Url.Https {
-			prefix = append(prefix, urlHttps)
-		}
-		if len(prefix) == 0 {
-			prefix = []string{"http", "https"}
-		}
-		expression := `^((` + strings.Join(prefix, "|") + `):\/\/)[-a-zA-Z0-9@:%._\\+~#?&\/=]{2,256}\.[a-z]{2,6}\b([-a-zA-Z0-9@:%._\\+~#?&\/=]*)$`
-		regx, err := regexp.Compile(expression)
-		if err != nil || !regx.MatchString(value) {
+		if !urlRegexes[*s.Url].MatchString(value) {
 			appendErrorBags(
 				bags,
-				fmt.Sprintf("%s is not a valid url", key),
+				key,
+				FieldError{Rule: RuleUrl, Params: map[string]any{"schemes": s.Url.schemes()}, Value: value},
+				"url",
 				s.Message.Url,
 			)
 			return StringValidationError
@@ -368,14 +433,17 @@ func (s String) assertUrl(key string, value string, bags *[]string) error {
 	return nil
 }
 
-func (s String) assertCustomValidation(fc func(v string, path PathKey, look Lookup) error, jsonSource []byte, value any, path PathKey, bags *[]string) error {
+func (s String) assertCustomValidation(fc func(v string, path PathKey, look Lookup) error, jsonSource []byte, value any, path PathKey, bags *[]FieldError) error {
 	err := fc(value.(string), path, func(k string) gjson.Result {
 		return gjson.GetBytes(jsonSource, k)
 	})
 	if err != nil {
+		fe, messageKey := customFieldError(err, value)
 		appendErrorBags(
 			bags,
-			err.Error(),
+			path.Current,
+			fe,
+			messageKey,
 			s.Message.Custom,
 		)
 		return StringValidationError
@@ -10,21 +10,21 @@ import (
 func Test_String_Required(t *testing.T) {
 	t.Run("it should error when the property is not exist", func(t *testing.T) {
 		schema := String{Required: true}
-		_, err := schema.validate([]byte{}, "", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
 	})
 	t.Run("it should error when the property value is null", func(t *testing.T) {
 		schema := String{Required: true}
-		_, err := schema.validate([]byte{}, nil, RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, nil, RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
 	})
 	t.Run("it should error when the property value is empty string", func(t *testing.T) {
 		schema := String{Required: true}
-		_, err := schema.validate([]byte{}, "", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -34,7 +34,7 @@ func Test_String_Required(t *testing.T) {
 func Test_String_Value_Type(t *testing.T) {
 	t.Run("it should error when the property value is not a string", func(t *testing.T) {
 		schema := String{Required: true}
-		_, err := schema.validate([]byte{}, 123, RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, 123, RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -52,7 +52,7 @@ func Test_String_RequiredIf(t *testing.T) {
 			FieldPath: "test_1",
 			Value:     "x",
 		}}
-		_, err := schema.validate(jsonBytes, "", RuleParams{Key: "test"})
+		_, err := schema.Validate(jsonBytes, "", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -67,7 +67,7 @@ func Test_String_RequiredIf(t *testing.T) {
 			FieldPath: "test_1",
 			Value:     "x",
 		}}
-		_, err := schema.validate(jsonBytes, "", RuleParams{Key: "test"})
+		_, err := schema.Validate(jsonBytes, "", RuleContext{Key: "test"})
 		if errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, nil)
 		}
@@ -85,7 +85,7 @@ func Test_String_RequiredUnless(t *testing.T) {
 			FieldPath: "test_1",
 			Value:     "x",
 		}}
-		_, err := schema.validate(jsonBytes, "", RuleParams{Key: "test"})
+		_, err := schema.Validate(jsonBytes, "", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -100,7 +100,7 @@ func Test_String_RequiredUnless(t *testing.T) {
 			FieldPath: "test_1",
 			Value:     "x",
 		}}
-		_, err := schema.validate(jsonBytes, "", RuleParams{Key: "test"})
+		_, err := schema.Validate(jsonBytes, "", RuleContext{Key: "test"})
 		if errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, nil)
 		}
@@ -110,7 +110,7 @@ func Test_String_RequiredUnless(t *testing.T) {
 func Test_String_Min(t *testing.T) {
 	t.Run("it should error when the length of property value is not bigger than or equal to x", func(t *testing.T) {
 		schema := String{Min: 2}
-		_, err := schema.validate([]byte{}, "x", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "x", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -120,7 +120,7 @@ func Test_String_Min(t *testing.T) {
 func Test_String_Max(t *testing.T) {
 	t.Run("it should error when the length of property value is not less than or equal to x", func(t *testing.T) {
 		schema := String{Max: 2}
-		_, err := schema.validate([]byte{}, "xxxx", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "xxxx", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -130,7 +130,7 @@ func Test_String_Max(t *testing.T) {
 func Test_String_Regex(t *testing.T) {
 	t.Run("it should error when the property value is not match the expression", func(t *testing.T) {
 		schema := String{Regex: "^test$"}
-		_, err := schema.validate([]byte{}, "test2", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "test2", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -140,7 +140,7 @@ func Test_String_Regex(t *testing.T) {
 func Test_String_NotRegex(t *testing.T) {
 	t.Run("it should error when the property value is match the expression", func(t *testing.T) {
 		schema := String{NotRegex: "^test$"}
-		_, err := schema.validate([]byte{}, "test", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "test", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -150,7 +150,7 @@ func Test_String_NotRegex(t *testing.T) {
 func Test_String_In(t *testing.T) {
 	t.Run("it should error when the property value is not on the list", func(t *testing.T) {
 		schema := String{In: []string{"one", "two", "three"}}
-		_, err := schema.validate([]byte{}, "four", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "four", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -160,7 +160,7 @@ func Test_String_In(t *testing.T) {
 func Test_String_NotIn(t *testing.T) {
 	t.Run("it should error when the property value is on the list", func(t *testing.T) {
 		schema := String{NotIn: []string{"one", "two", "three"}}
-		_, err := schema.validate([]byte{}, "one", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "one", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -177,7 +177,7 @@ func Test_String_Email(t *testing.T) {
 	for _, cs := range cases {
 		t.Run("it should error when the property value is not valid email e.g "+cs, func(t *testing.T) {
 			schema := String{Email: true}
-			_, err := schema.validate([]byte{}, cs, RuleParams{Key: "test"})
+			_, err := schema.Validate([]byte{}, cs, RuleContext{Key: "test"})
 			if !errors.Is(err, StringValidationError) {
 				t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 			}
@@ -188,7 +188,7 @@ func Test_String_Email(t *testing.T) {
 func Test_String_Alpha(t *testing.T) {
 	t.Run("it should error when the property value is not alphabetical", func(t *testing.T) {
 		schema := String{Alpha: true}
-		_, err := schema.validate([]byte{}, "test123", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "test123", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -198,7 +198,7 @@ func Test_String_Alpha(t *testing.T) {
 func Test_String_AlphaNumeric(t *testing.T) {
 	t.Run("it should error when the property value is not alphabetical and numeric", func(t *testing.T) {
 		schema := String{AlphaNumeric: true}
-		_, err := schema.validate([]byte{}, "_test_", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "_test_", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -215,7 +215,7 @@ func Test_String_Url(t *testing.T) {
 	for _, cs := range cases {
 		t.Run("it should error when the property value is not valid url e.g "+cs, func(t *testing.T) {
 			schema := String{Url: &Url{Http: true, Https: true}}
-			_, err := schema.validate([]byte{}, cs, RuleParams{Key: "test"})
+			_, err := schema.Validate([]byte{}, cs, RuleContext{Key: "test"})
 			if !errors.Is(err, StringValidationError) {
 				t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 			}
@@ -228,7 +228,7 @@ func Test_String_Custom_Validation(t *testing.T) {
 		schema := String{Custom: func(v string, _ PathKey, look Lookup) error {
 			return StringValidationError
 		}}
-		_, err := schema.validate([]byte{}, "_test_", RuleParams{Key: "test"})
+		_, err := schema.Validate([]byte{}, "_test_", RuleContext{Key: "test"})
 		if !errors.Is(err, StringValidationError) {
 			t.Errorf("Actual = %v, Expected = %v", err, StringValidationError)
 		}
@@ -238,8 +238,8 @@ func Test_String_Custom_Validation(t *testing.T) {
 func Test_String_Custom_Message(t *testing.T) {
 	t.Run("it should return custom message error when the custom message is configured.", func(t *testing.T) {
 		schema := String{Min: 5, Message: StringErrorMessage{Min: "minimum 2"}}
-		bags, _ := schema.validate([]byte{}, "tst", RuleParams{Key: "test"})
-		if !slices.Contains(bags, "minimum 2") {
+		bags, _ := schema.Validate([]byte{}, "tst", RuleContext{Key: "test"})
+		if !slices.ContainsFunc(bags, func(fe FieldError) bool { return fe.Message == "minimum 2" }) {
 			t.Fatalf("Actual = %v, Expected contain = minimum 2", bags)
 		}
 	})
@@ -0,0 +1,398 @@
+package validet
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const structTagName = "validet"
+
+var ErrInvalidStruct = errors.New("value must be a struct or a pointer to a struct")
+
+// ValidateStruct validates a Go struct using its `validet` struct tags.
+//
+// Rules are separated by commas and arguments follow an equal sign, e.g.
+// `validet:"required,min=3,max=50,email"`. List arguments (in, not_in, url)
+// and conditions (required_if, required_unless) are separated by spaces:
+// `validet:"in=admin member"` or `validet:"required_if=type company"`. Use
+// `\,` for a literal comma inside an argument. Nested structs become Object
+// rules and slices of structs become SliceObject rules, except time.Time and
+// the types implementing encoding.TextUnmarshaler or json.Unmarshaler, which
+// are validated as strings in their text form, RFC 3339 for time.Time, and
+// are absent when zero. Recursive types are rejected. Error keys use the
+// json tag names of the fields.
+func ValidateStruct(v any) (ErrorBag, error) {
+	data, rules, err := StructSchema(v)
+	if err != nil {
+		return ErrorBag{}, err
+	}
+	return validate(data, rules, Options{})
+}
+
+// StructSchema converts a struct into the data and the rules ValidateStruct
+// would use, so they can be validated with NewSchema and custom Options.
+func StructSchema(v any) (DataObject, SchemaRules, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, nil, ErrInvalidStruct
+	}
+
+	item, err := structRules(rv.Type(), map[reflect.Type]bool{})
+	if err != nil {
+		return nil, nil, err
+	}
+	rules := SchemaRules{}
+	for k, r := range item {
+		rules[k] = r.(Rule)
+	}
+	return structData(rv).(DataObject), rules, nil
+}
+
+type structField struct {
+	name  string
+	index []int
+	field reflect.StructField
+}
+
+// structFields lists the JSON fields of t, promoting the fields of embedded
+// structs like encoding/json.
+func structFields(t reflect.Type) ([]structField, error) {
+	return embeddedFields(t, map[reflect.Type]bool{})
+}
+
+// embeddedFields collects the fields of structFields. visiting holds the
+// embedding structs, to reject the types that embed themselves.
+func embeddedFields(t reflect.Type, visiting map[reflect.Type]bool) ([]structField, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("recursive type %s", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, tagged := jsonFieldName(f)
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && !tagged {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				promoted, err := embeddedFields(ft, visiting)
+				if err != nil {
+					return nil, err
+				}
+				for _, embedded := range promoted {
+					embedded.index = append([]int{i}, embedded.index...)
+					fields = append(fields, embedded)
+				}
+				continue
+			}
+		}
+		fields = append(fields, structField{name: name, index: []int{i}, field: f})
+	}
+	return fields, nil
+}
+
+func jsonFieldName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "-", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return f.Name, false
+	}
+	return name, true
+}
+
+// structRules converts the fields of t into rules. visiting holds the struct
+// types being converted, to reject the types that contain themselves.
+func structRules(t reflect.Type, visiting map[reflect.Type]bool) (SchemaObject, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("recursive type %s", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	fields, err := structFields(t)
+	if err != nil {
+		return nil, err
+	}
+	item := SchemaObject{}
+	for _, f := range fields {
+		tag := f.field.Tag.Get(structTagName)
+		if tag == "-" {
+			continue
+		}
+		specs := parseStructTag(tag)
+		rule, err := structFieldRule(f.field.Type, specs, visiting)
+		if err != nil {
+			return nil, fmt.Errorf("validet: field %s: %w", f.field.Name, err)
+		}
+		if rule != nil {
+			item[f.name] = rule
+		}
+	}
+	return item, nil
+}
+
+func structFieldRule(t reflect.Type, specs []ruleSpec, visiting map[reflect.Type]bool) (Rule, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if structLeaf(t) {
+		if len(specs) == 0 {
+			return nil, nil
+		}
+		return stringRule(specs)
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		item, err := structRules(t, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return objectRule(specs, item)
+	case reflect.Slice, reflect.Array:
+		elem := t.Elem()
+		if elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+		if structLeaf(elem) {
+			if len(specs) == 0 {
+				return nil, nil
+			}
+			return sliceRule[string](specs)
+		}
+		if elem.Kind() == reflect.Struct {
+			item, err := structRules(elem, visiting)
+			if err != nil {
+				return nil, err
+			}
+			return sliceObjectRule(specs, item)
+		}
+		if len(specs) == 0 {
+			return nil, nil
+		}
+		switch elem.Kind() {
+		case reflect.String:
+			return sliceRule[string](specs)
+		case reflect.Int, reflect.Int8, reflect.Int16:
+			return sliceRule[int](specs)
+		case reflect.Int32:
+			return sliceRule[int32](specs)
+		case reflect.Int64:
+			return sliceRule[int64](specs)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16:
+			return sliceRule[uint](specs)
+		case reflect.Uint32:
+			return sliceRule[uint32](specs)
+		case reflect.Uint64:
+			return sliceRule[uint64](specs)
+		case reflect.Float32:
+			return sliceRule[float32](specs)
+		case reflect.Float64:
+			return sliceRule[float64](specs)
+		}
+	}
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return stringRule(specs)
+	case reflect.Bool:
+		return booleanRule(specs)
+	case reflect.Int, reflect.Int8, reflect.Int16:
+		return numericRule[int](specs)
+	case reflect.Int32:
+		return numericRule[int32](specs)
+	case reflect.Int64:
+		return numericRule[int64](specs)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return numericRule[uint](specs)
+	case reflect.Uint32:
+		return numericRule[uint32](specs)
+	case reflect.Uint64:
+		return numericRule[uint64](specs)
+	case reflect.Float32:
+		return numericRule[float32](specs)
+	case reflect.Float64:
+		return numericRule[float64](specs)
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return objectRule(specs, nil)
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// structLeaf reports whether the struct or map type t holds a single value,
+// such as time.Time, rather than fields to validate.
+func structLeaf(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct && t.Kind() != reflect.Map {
+		return false
+	}
+	if t == timeType {
+		return true
+	}
+	pt := reflect.PointerTo(t)
+	return pt.Implements(textUnmarshalerType) || pt.Implements(jsonUnmarshalerType)
+}
+
+// structLeafData converts a value of a structLeaf type to its text form, or
+// to nil when it is zero.
+func structLeafData(rv reflect.Value) any {
+	if rv.IsZero() {
+		return nil
+	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	switch v := ptr.Interface().(type) {
+	case encoding.TextMarshaler:
+		if text, err := v.MarshalText(); err == nil {
+			return string(text)
+		}
+	case json.Marshaler:
+		if source, err := v.MarshalJSON(); err == nil {
+			var data any
+			if json.Unmarshal(source, &data) == nil {
+				return data
+			}
+		}
+	}
+	return rv.Interface()
+}
+
+func parseStructTag(tag string) []ruleSpec {
+	var specs []ruleSpec
+	for i, part := range splitEscaped(tag, ',') {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+		specs = append(specs, ruleSpec{
+			name: strings.TrimSpace(name),
+			arg:  arg,
+			sep:  " ",
+			pos:  i,
+		})
+	}
+	return specs
+}
+
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+	var current strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] == sep {
+			current.WriteByte(sep)
+			i++
+			continue
+		}
+		if s[i] == sep {
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(s[i])
+	}
+	return append(parts, current.String())
+}
+
+func structData(rv reflect.Value) any {
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return structData(rv.Elem())
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16:
+		return int(rv.Int())
+	case reflect.Int32:
+		return int32(rv.Int())
+	case reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return uint(rv.Uint())
+	case reflect.Uint32:
+		return uint32(rv.Uint())
+	case reflect.Uint64:
+		return rv.Uint()
+	case reflect.Float32:
+		return float32(rv.Float())
+	case reflect.Float64:
+		return rv.Float()
+	case reflect.Struct:
+		if structLeaf(rv.Type()) {
+			return structLeafData(rv)
+		}
+		// structRules already rejected the recursive types.
+		fields, _ := structFields(rv.Type())
+		data := DataObject{}
+		for _, f := range fields {
+			fv, err := rv.FieldByIndexErr(f.index)
+			if err != nil {
+				continue
+			}
+			data[f.name] = structData(fv)
+		}
+		return data
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		values := make([]any, rv.Len())
+		for i := range values {
+			values[i] = structData(rv.Index(i))
+		}
+		return values
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		if structLeaf(rv.Type()) {
+			return structLeafData(rv)
+		}
+		if rv.Type().Key().Kind() == reflect.String {
+			data := DataObject{}
+			iter := rv.MapRange()
+			for iter.Next() {
+				data[iter.Key().String()] = structData(iter.Value())
+			}
+			return data
+		}
+	}
+	return rv.Interface()
+}
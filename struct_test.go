@@ -0,0 +1,122 @@
+package validet
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type structTestAddress struct {
+	City string `json:"city" validet:"required"`
+	Zip  string `json:"zip_code" validet:"regex=^[0-9]{5}$"`
+}
+
+type structTestItem struct {
+	Title string `json:"title" validet:"required,max=5"`
+	Qty   int    `json:"qty" validet:"min=1"`
+}
+
+type structTestRequest struct {
+	Name     string             `json:"name" validet:"required,min=3"`
+	Email    string             `json:"email" validet:"email"`
+	Type     string             `json:"type" validet:"in=personal company"`
+	Company  string             `json:"company_name" validet:"required_if=type company"`
+	Age      int64              `json:"age" validet:"max=150"`
+	Tags     []string           `json:"tags" validet:"max=2"`
+	Address  *structTestAddress `json:"address" validet:"required"`
+	Items    []structTestItem   `json:"items" validet:"min=1"`
+	Internal string             `json:"-" validet:"required"`
+}
+
+func Test_ValidateStruct(t *testing.T) {
+	t.Run("it should pass when every tagged rule is satisfied", func(t *testing.T) {
+		bags, err := ValidateStruct(structTestRequest{
+			Name:    "tono",
+			Email:   "tono@mail.com",
+			Type:    "personal",
+			Age:     20,
+			Tags:    []string{"a"},
+			Address: &structTestAddress{City: "Jakarta", Zip: "12345"},
+			Items:   []structTestItem{{Title: "pen", Qty: 1}},
+		})
+		if err != nil {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, nil)
+		}
+	})
+	t.Run("it should report errors keyed by the json names", func(t *testing.T) {
+		bags, err := ValidateStruct(&structTestRequest{
+			Name:  "to",
+			Email: "tono",
+			Type:  "company",
+			Tags:  []string{"a", "b", "c"},
+			Items: []structTestItem{{Title: "notebook", Qty: 2}},
+		})
+		if err == nil {
+			t.Fatalf("Actual = %v, Expected an error", err)
+		}
+		for _, key := range []string{"name", "email", "company_name", "tags", "address", "items.0.title"} {
+			if _, ok := bags.Errors[key]; !ok {
+				t.Errorf("Actual = %v, Expected key = %s", bags.Errors, key)
+			}
+		}
+	})
+	t.Run("it should return an error for invalid tags", func(t *testing.T) {
+		type invalid struct {
+			Name string `validet:"min=abc"`
+		}
+		if _, err := ValidateStruct(invalid{}); err == nil {
+			t.Errorf("Actual = %v, Expected an error", err)
+		}
+	})
+	t.Run("it should return an error when the value is not a struct", func(t *testing.T) {
+		if _, err := ValidateStruct("test"); !errors.Is(err, ErrInvalidStruct) {
+			t.Errorf("Actual = %v, Expected = %v", err, ErrInvalidStruct)
+		}
+	})
+	t.Run("it should validate time.Time fields as RFC 3339 strings", func(t *testing.T) {
+		type event struct {
+			When  time.Time   `json:"when" validet:"required"`
+			Until *time.Time  `json:"until" validet:"regex=^2024-"`
+			Dates []time.Time `json:"dates" validet:"max=1"`
+		}
+		when := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		if bags, err := ValidateStruct(event{When: when, Until: &when, Dates: []time.Time{when}}); err != nil {
+			t.Errorf("Actual = %v, Expected no errors", bags.Errors)
+		}
+		data, _, _ := StructSchema(event{When: when})
+		if data["when"] != "2024-05-01T10:00:00Z" {
+			t.Errorf("Actual = %v, Expected = %v", data["when"], "2024-05-01T10:00:00Z")
+		}
+		bags, _ := ValidateStruct(event{})
+		expected := map[string][]string{"when": {"when is required"}}
+		if !reflect.DeepEqual(bags.Errors, expected) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, expected)
+		}
+	})
+	t.Run("it should reject recursive types", func(t *testing.T) {
+		type node struct {
+			Name     string `json:"name" validet:"required"`
+			Children []node `json:"children"`
+		}
+		_, err := ValidateStruct(node{Name: "root"})
+		if err == nil || !strings.Contains(err.Error(), "recursive type") {
+			t.Errorf("Actual = %v, Expected a recursive type error", err)
+		}
+	})
+	t.Run("it should reject structs embedding a pointer to themselves", func(t *testing.T) {
+		type Loop struct {
+			*Loop
+			X string `json:"x" validet:"required"`
+		}
+		_, err := ValidateStruct(Loop{X: "x"})
+		if err == nil || !strings.Contains(err.Error(), "recursive type") {
+			t.Errorf("Actual = %v, Expected a recursive type error", err)
+		}
+		_, _, err = Bind[Loop](DataObject{"x": "x"}, SchemaRules{"x": String{Required: true}}, Options{})
+		if err == nil || !strings.Contains(err.Error(), "recursive type") {
+			t.Errorf("Actual = %v, Expected a recursive type error", err)
+		}
+	})
+}
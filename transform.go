@@ -0,0 +1,91 @@
+package validet
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transformer cleans a string value before it is validated. The transformers
+// of a String run in order on a copy of the value; the cleaned value is the
+// one validated and the one collected by Bind and Validated, the data itself
+// is never modified. Any func(string) string can be used.
+type Transformer func(string) string
+
+var tagRegex = regexp.MustCompile(`<[a-zA-Z/!?][^>]*>`)
+
+// Trim removes the leading and trailing white space.
+func Trim(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// Lowercase maps the letters to lower case.
+func Lowercase(s string) string {
+	return strings.ToLower(s)
+}
+
+// Uppercase maps the letters to upper case.
+func Uppercase(s string) string {
+	return strings.ToUpper(s)
+}
+
+// CollapseSpaces replaces every run of white space with a single space.
+func CollapseSpaces(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// StripTags removes HTML tags and comments, keeping their text content.
+func StripTags(s string) string {
+	return tagRegex.ReplaceAllString(s, "")
+}
+
+// NormalizeNFC applies the canonical composition of unicode, so that "é"
+// typed as "e" and a combining accent equals the precomposed "é".
+func NormalizeNFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// NormalizeNFKC applies the compatibility composition of unicode, which also
+// folds look-alike forms such as the full width "Ａ" or the ligature "ﬁ" into
+// their plain letters.
+func NormalizeNFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// transformers are the built-in transformers by the name used in struct
+// tags, rule strings and definitions.
+var transformers = map[string]Transformer{
+	"trim":            Trim,
+	"lowercase":       Lowercase,
+	"uppercase":       Uppercase,
+	"collapse_spaces": CollapseSpaces,
+	"strip_tags":      StripTags,
+	"nfc":             NormalizeNFC,
+	"nfkc":            NormalizeNFKC,
+}
+
+func (s String) transform(value string) string {
+	for _, fn := range s.Transform {
+		value = fn(value)
+	}
+	return value
+}
@@ -0,0 +1,149 @@
+package validet
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Transform(t *testing.T) {
+	t.Run("it should validate and bind the cleaned values", func(t *testing.T) {
+		data := DataObject{
+			"email": "  Tono@Mail.COM ",
+			"profile": DataObject{
+				"bio": "<p>Hello\n\n  <b>world</b></p>",
+			},
+		}
+		schema := SchemaRules{
+			"email": String{Required: true, Email: true, Transform: []Transformer{Trim, Lowercase}},
+			"profile": Object{Item: SchemaObject{
+				"bio": String{Max: 11, Transform: []Transformer{StripTags, CollapseSpaces}},
+			}},
+		}
+		cleaned, bags, err := Bind[DataObject](data, schema, Options{})
+		if err != nil {
+			t.Fatalf("unexpected errors %v", bags.Errors)
+		}
+		expected := DataObject{
+			"email":   "tono@mail.com",
+			"profile": DataObject{"bio": "Hello world"},
+		}
+		if !reflect.DeepEqual(cleaned, expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", cleaned, expected)
+		}
+		if data["email"] != "  Tono@Mail.COM " || data["profile"].(DataObject)["bio"] != "<p>Hello\n\n  <b>world</b></p>" {
+			t.Errorf("the data was modified: %#v", data)
+		}
+	})
+
+	t.Run("it should return the cleaned values without Bind", func(t *testing.T) {
+		schema := SchemaRules{
+			"name": String{Required: true, Max: 4, Transform: []Transformer{Trim, NormalizeNFC}},
+			"role": String{Default: "member"},
+			"age":  Numeric[int]{Min: 17},
+		}
+		expected := DataObject{"name": "Jos\u00e9", "role": "member", "age": 20}
+
+		container := NewSchema(DataObject{"name": " Jose\u0301 ", "age": 20, "extra": true}, schema, Options{})
+		if container.Validated() != nil {
+			t.Errorf("Actual = %v, Expected = %v", container.Validated(), nil)
+		}
+		if _, err := container.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(container.Validated(), expected) {
+			t.Errorf("Actual = %#v, Expected = %#v", container.Validated(), expected)
+		}
+
+		source := []byte(`{"name": " Jose\u0301 ", "age": "20", "extra": true}`)
+		compiled, err := Compile(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		validated, _, err := compiled.ValidatedJSON(source, Options{Coerce: true})
+		if err != nil || !reflect.DeepEqual(validated, expected) {
+			t.Errorf("Actual = %#v %v, Expected = %#v", validated, err, expected)
+		}
+		validated, _, err = compiled.Validated(DataObject{"name": " Jose\u0301 ", "age": 20}, Options{})
+		if err != nil || !reflect.DeepEqual(validated, expected) {
+			t.Errorf("Actual = %#v %v, Expected = %#v", validated, err, expected)
+		}
+		validated, _, err = ValidatedJSON(source, schema, Options{Coerce: true})
+		if err != nil || !reflect.DeepEqual(validated, expected) {
+			t.Errorf("Actual = %#v %v, Expected = %#v", validated, err, expected)
+		}
+
+		validated, bags, err := ValidatedJSON([]byte(`{"name": "Tono Tono"}`), schema, Options{})
+		if err == nil || validated != nil || len(bags.Errors["name"]) != 1 {
+			t.Errorf("Actual = %#v %v, Expected = a name error", validated, bags.Errors)
+		}
+		if _, _, err := ValidatedJSON([]byte(`[]`), schema, Options{}); err != ErrInvalidJSON {
+			t.Errorf("Actual = %v, Expected = %v", err, ErrInvalidJSON)
+		}
+		if _, err := container.Validate(); err != nil || container.Validated() == nil {
+			t.Errorf("Actual = %v, Expected the validated values to be kept", err)
+		}
+		container.Data = DataObject{}
+		if _, err := container.Validate(); err == nil || container.Validated() != nil {
+			t.Errorf("Actual = %v, Expected = %v", container.Validated(), nil)
+		}
+	})
+
+	t.Run("it should check required against the cleaned value", func(t *testing.T) {
+		bags, _ := ValidateJSON([]byte(`{"name": "   "}`), SchemaRules{
+			"name": String{Required: true, Transform: []Transformer{Trim}},
+		}, Options{})
+		if _, ok := bags.Errors["name"]; !ok {
+			t.Errorf("Actual = %v, Expected a name error", bags.Errors)
+		}
+	})
+
+	t.Run("it should clean with the built-in transformers", func(t *testing.T) {
+		tests := []struct {
+			fn       Transformer
+			value    string
+			expected string
+		}{
+			{Trim, " \ta b\n", "a b"},
+			{Lowercase, "ÀBC", "àbc"},
+			{Uppercase, "àbc", "ÀBC"},
+			{CollapseSpaces, " a \t\n b  ", " a b "},
+			{StripTags, `<a href="/x">link</a><!-- note --> 1 < 2 <br/>`, "link 1 < 2 "},
+			{NormalizeNFC, "Jose\u0301", "Jos\u00e9"},
+			{NormalizeNFC, "\uff21\ufb01", "\uff21\ufb01"},
+			{NormalizeNFKC, "\uff21\ufb01", "Afi"},
+		}
+		for _, tt := range tests {
+			if actual := tt.fn(tt.value); actual != tt.expected {
+				t.Errorf("Actual = %q, Expected = %q", actual, tt.expected)
+			}
+		}
+	})
+
+	t.Run("it should read transformers from rule strings and definitions", func(t *testing.T) {
+		RegisterCustom("dashes", func(v string) string { return strings.ReplaceAll(v, " ", "-") })
+		definition, err := ParseDefinition([]byte("fields:\n  slug:\n    type: string\n    transform: [trim, lowercase, dashes]\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules, err := definition.Rules()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rule, err := ParseRule("required|nfkc|trim|collapse_spaces|uppercase")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := rules["slug"].(String).transform(" Hello World "); actual != "hello-world" {
+			t.Errorf("Actual = %q, Expected = %q", actual, "hello-world")
+		}
+		if actual := rule.(String).transform(" a   \ufb01 "); actual != "A FI" {
+			t.Errorf("Actual = %q, Expected = %q", actual, "A FI")
+		}
+
+		definition, _ = ParseDefinition([]byte("fields:\n  age: {type: int, transform: [trim]}\n"))
+		if _, err := definition.Rules(); err == nil || !strings.Contains(err.Error(), `"transform" is only supported by string`) {
+			t.Errorf("Actual = %v", err)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package validet
 
-import "github.com/tidwall/gjson"
+import (
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
 
 type Int = int
 
@@ -17,12 +21,44 @@ type PathKey struct {
 	Current  string
 }
 
+// RequiredIf makes a field required when the field at FieldPath equals
+// Value. See conditionMatches for how the values are compared.
 type RequiredIf struct {
 	FieldPath string
 	Value     any
 }
 
+// RequiredUnless makes a field required unless the field at FieldPath
+// equals Value, like RequiredIf.
 type RequiredUnless struct {
 	FieldPath string
 	Value     any
 }
+
+func (r RequiredIf) matches(source []byte) bool {
+	return conditionMatches(gjson.GetBytes(source, r.FieldPath), r.Value)
+}
+
+func (r RequiredUnless) matches(source []byte) bool {
+	return conditionMatches(gjson.GetBytes(source, r.FieldPath), r.Value)
+}
+
+// conditionMatches compares the other field of a condition by the type of
+// the expected value: booleans match JSON booleans, numbers of any Go type
+// match JSON numbers of the same value and nil matches absent and null
+// fields. Strings, as read from struct tags and definitions, match the text
+// of any JSON value, e.g. "18" matches 18 and "true" matches true.
+func conditionMatches(result gjson.Result, value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return !result.Exists() || result.Type == gjson.Null
+	case string:
+		return result.String() == v
+	case bool:
+		return (result.Type == gjson.True || result.Type == gjson.False) && result.Bool() == v
+	}
+	if n, ok := numericAsFloat(value); ok {
+		return result.Type == gjson.Number && result.Num == n
+	}
+	return result.Exists() && result.String() == fmt.Sprint(value)
+}
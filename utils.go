@@ -1,17 +1,36 @@
 package validet
 
 import (
-	"fmt"
+	"errors"
 	"reflect"
+	"regexp"
 )
 
-func appendErrorBags(bags *[]string, om string, cm string) {
+func appendErrorBags(bags *[]FieldError, key string, fe FieldError, messageKey string, cm string) {
 	errorBags := *bags
-	message := om
-	if cm != "" {
-		message = cm
+	fe.messageKey = messageKey
+	fe.template = cm
+	fe.Message = fe.render(key, Options{})
+	*bags = append(errorBags, fe)
+}
+
+// customFieldError converts the error of a Custom func. A FieldError keeps
+// its rule code, parameters and message key, or its rule code when it has
+// no message key, so that it is rendered like a built-in rule. Any other
+// error is a RuleCustom error with the message of the error.
+func customFieldError(err error, value any) (FieldError, string) {
+	var fe FieldError
+	if !errors.As(err, &fe) {
+		return FieldError{Rule: RuleCustom, Value: value, Message: err.Error()}, ""
+	}
+	if fe.Rule == "" {
+		fe.Rule = RuleCustom
 	}
-	*bags = append(errorBags, message)
+	messageKey := fe.messageKey
+	if messageKey == "" {
+		messageKey = fe.Rule
+	}
+	return FieldError{Rule: fe.Rule, Params: fe.Params, Value: value, Message: fe.Message}, messageKey
 }
 
 func isObjectValue(value any) bool {
@@ -34,6 +53,12 @@ func stringLength(value any) int {
 	return len([]rune(stringValue))
 }
 
-func msgf(format string, v ...any) string {
-	return fmt.Sprintf(format, v...)
+// ruleRegex returns the regex compiled by Compile, or compiles the pattern
+// of a rule validated without Compile. Nothing is cached between calls:
+// schemas validated repeatedly should be compiled once instead.
+func ruleRegex(compiled *regexp.Regexp, pattern string) (*regexp.Regexp, error) {
+	if compiled != nil {
+		return compiled, nil
+	}
+	return regexp.Compile(pattern)
 }
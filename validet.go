@@ -3,22 +3,47 @@ package validet
 import (
 	"encoding/json"
 	"errors"
-	"reflect"
 	"strings"
+
+	"github.com/tidwall/gjson"
 )
 
 type Options struct {
 	AbortEarly bool
+	// Messages overrides the message templates of the built-in rules for
+	// this validation. See Messages for the template syntax.
+	Messages Messages
+	// Locale selects the message bundle, e.g. LocaleIndonesian. It defaults
+	// to DefaultLocale().
+	Locale string
+	// Attributes maps field paths to the display names used by the {field}
+	// placeholder of messages. See Attributes.
+	Attributes Attributes
+	// Coerce converts the strings of form, query and CSV data, and the
+	// float64 numbers of decoded JSON, to the type of the rule: numeric
+	// strings and numbers to the NT of Numeric and Slice, "true", "false",
+	// "1", "0", "on" and "off" to booleans, and blank strings to absent
+	// numbers and booleans. A conversion that would lose data, such as 1.5
+	// into an int or 5000000000 into a uint32, fails with a type error. The
+	// converted values are the ones collected by Bind and Validated.
+	Coerce bool
 }
 
 type Validation struct {
 	data    DataObject
-	schema  SchemaRules
+	source  []byte
+	schema  any
 	options Options
+	output  DataObject
 }
 
 func (v *Validation) check(b *ErrorBag) {
-	errorBags := *b
+	errorBags := b
+
+	if v.source != nil {
+		mapSchemas(v.source, []string{}, "", gjson.ParseBytes(v.source), v.schema, errorBags, v.options, v.output)
+		return
+	}
 
 	jsonData, err := json.Marshal(v.data)
 
@@ -26,36 +51,41 @@ func (v *Validation) check(b *ErrorBag) {
 		jsonData = []byte{}
 	}
 
-	mapSchemas(jsonData, []string{}, "", v.data, v.schema, &errorBags, v.options)
+	mapSchemas(jsonData, []string{}, "", v.data, v.schema, errorBags, v.options, v.output)
 }
 
-func mapSchemas(jsonString []byte, pathKey []string, key string, data any, schema any, b *ErrorBag, option Options) {
-	errorBags := *b
+func mapSchemas(jsonString []byte, pathKey []string, key string, data any, schema any, b *ErrorBag, option Options, output DataObject) {
+	errorBags := b
 
-	schemaData := data.(DataObject)
-	if isSchemaRule(schema) {
-		schemaRules := schema.(map[string]Rule)
-		for scKey, scRules := range schemaRules {
-			mapSchemas(jsonString, pathKey, scKey, schemaData, scRules, &errorBags, option)
+	if fields, ok := schemaFields(schema); ok {
+		for _, field := range fields {
+			mapSchemas(jsonString, pathKey, field.Key, data, field.Rule, errorBags, option, output)
 			if option.AbortEarly && len(errorBags.Errors) > 0 {
 				return
 			}
 		}
 	} else {
 		if schemaRule, ok := isRule(schema); ok {
-			if schemaRule.isMyTypeOf(schema) {
-				bags, err := schemaRule.process(RuleParams{
+			if schemaRule.IsMyTypeOf(schema) {
+				ctx := RuleContext{
 					OriginalData: jsonString,
-					DataKey:      schemaData,
+					DataKey:      data,
 					PathKey:      pathKey,
 					Key:          key,
 					Schema:       schemaRule,
-					ErrorBags:    &errorBags,
+					ErrorBags:    errorBags,
 					Option:       option,
-				})
+					Output:       output,
+				}
+				if output != nil {
+					if value := ctx.Value(); value != nil {
+						output[key] = value
+					}
+				}
+				bags, err := schemaRule.Process(ctx)
 				if err != nil {
-					path := append(pathKey, key)
-					errorBags.append(strings.Join(path, "."), bags)
+					path := append(append([]string{}, pathKey...), key)
+					appendFieldErrors(errorBags, path, key, bags, option)
 					if option.AbortEarly {
 						return
 					}
@@ -65,13 +95,60 @@ func mapSchemas(jsonString []byte, pathKey []string, key string, data any, schem
 	}
 }
 
+// appendFieldErrors records the errors of the field at path, filling their
+// Path and rendering their messages with name as the default field name.
+func appendFieldErrors(errorBags *ErrorBag, path []string, name string, bags []FieldError, option Options) {
+	for i := range bags {
+		if len(bags[i].Path) == 0 {
+			bags[i].Path = path
+		}
+		bags[i].Message = bags[i].render(option.Attributes.name(path, name), option)
+	}
+	errorBags.append(strings.Join(path, "."), bags)
+}
+
 func validate(d DataObject, schema map[string]Rule, options Options) (ErrorBag, error) {
-	var errorBags = NewErrorBags()
-	validation := Validation{
+	return run(&Validation{
 		data:    d,
 		schema:  schema,
 		options: options,
+	})
+}
+
+// ValidateJSON validates a raw JSON object against the schema without
+// decoding it into a DataObject first. Field values are read straight from
+// the bytes, which are also used for RequiredIf, RequiredUnless and Lookup
+// queries.
+func ValidateJSON(source []byte, schema SchemaRules, options Options) (ErrorBag, error) {
+	if !validJSONObject(source) {
+		return ErrorBag{}, ErrInvalidJSON
+	}
+	return run(&Validation{
+		source:  source,
+		schema:  schema,
+		options: options,
+	})
+}
+
+// ValidatedJSON is ValidateJSON returning the validated values when the
+// validation passes, like the data returned by Bind.
+func ValidatedJSON(source []byte, schema SchemaRules, options Options) (DataObject, ErrorBag, error) {
+	if !validJSONObject(source) {
+		return nil, ErrorBag{}, ErrInvalidJSON
 	}
+	return runValidated(&Validation{
+		source:  source,
+		schema:  schema,
+		options: options,
+	})
+}
+
+func validJSONObject(source []byte) bool {
+	return gjson.ValidBytes(source) && gjson.ParseBytes(source).IsObject()
+}
+
+func run(validation *Validation) (ErrorBag, error) {
+	var errorBags = NewErrorBags()
 	validation.check(errorBags)
 
 	if len(errorBags.Errors) > 0 {
@@ -80,11 +157,32 @@ func validate(d DataObject, schema map[string]Rule, options Options) (ErrorBag,
 	return ErrorBag{}, nil
 }
 
-func isSchemaRule(val any) bool {
-	if value, ok := val.(map[string]Rule); ok {
-		return reflect.TypeOf(value) == reflect.TypeOf(map[string]Rule{})
+// runValidated runs the validation collecting the validated values, which
+// are returned when it passes.
+func runValidated(validation *Validation) (DataObject, ErrorBag, error) {
+	validation.output = DataObject{}
+	bags, err := run(validation)
+	if err != nil {
+		return nil, bags, err
+	}
+	return validation.output, bags, nil
+}
+
+func jsonValue(result gjson.Result) any {
+	if !result.Exists() || result.Type == gjson.Null {
+		return nil
+	}
+	return result.Value()
+}
+
+func schemaFields(val any) (OrderedRules, bool) {
+	switch value := val.(type) {
+	case OrderedRules:
+		return value, true
+	case map[string]Rule:
+		return sortedFields(value), true
 	}
-	return false
+	return nil, false
 }
 
 func isRule(val any) (Rule, bool) {
@@ -13,17 +13,16 @@ type CustomString struct {
 	Required bool
 }
 
-func (cs CustomString) validate(source []byte, key string, value any, option Options) ([]string, error) {
-	return []string{"jangan kosong"}, errors.New("error custom")
+func (cs CustomString) Validate(source []byte, value any, params RuleContext) ([]FieldError, error) {
+	return []FieldError{{Rule: "custom_string", Message: "jangan kosong"}}, errors.New("error custom")
 }
 
-func (cs CustomString) isMyTypeOf(schema any) bool {
+func (cs CustomString) IsMyTypeOf(schema any) bool {
 	return reflect.TypeOf(schema).Kind() == reflect.Struct && reflect.TypeOf(schema) == reflect.TypeOf(CustomString{})
 }
 
-func (cs CustomString) process(params RuleParams) ([]string, error) {
-	schemaData := params.DataKey.(DataObject)
-	return cs.validate(params.OriginalData, params.Key, schemaData[params.Key], params.Option)
+func (cs CustomString) Process(params RuleContext) ([]FieldError, error) {
+	return cs.Validate(params.OriginalData, params.Value(), params)
 }
 
 func TestValidate(t *testing.T) {
@@ -144,3 +143,147 @@ func TestValidate(t *testing.T) {
 
 	fmt.Println(string(jsonString))
 }
+
+func Test_ValidateJSON(t *testing.T) {
+	rules := SchemaRules{
+		"name":  String{Required: true, Min: 5},
+		"email": String{RequiredIf: &RequiredIf{FieldPath: "type", Value: "company"}, Email: true},
+		"information": Object{
+			Required: true,
+			Item: SchemaObject{
+				"age": Numeric[float64]{Required: true, Max: 100},
+			},
+		},
+		"items": SliceObject{
+			Item: SchemaObject{
+				"title": String{Required: true},
+			},
+		},
+	}
+	source := []byte(`{
+		"name": "tono",
+		"type": "company",
+		"information": {"age": 120},
+		"items": [{"title": "a"}, {"title": ""}]
+	}`)
+
+	t.Run("it should report the same errors as validating the decoded data", func(t *testing.T) {
+		bags, err := ValidateJSON(source, rules, Options{})
+		if err == nil {
+			t.Fatalf("Actual = %v, Expected an error", err)
+		}
+		data := DataObject{}
+		_ = json.Unmarshal(source, &data)
+		schema := NewSchema(data, rules, Options{})
+		expected, _ := schema.Validate()
+		if !reflect.DeepEqual(bags.Errors, expected.Errors) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Errors, expected.Errors)
+		}
+		for _, key := range []string{"name", "email", "information.age", "items.1.title"} {
+			if _, ok := bags.Errors[key]; !ok {
+				t.Errorf("Actual = %v, Expected key = %s", bags.Errors, key)
+			}
+		}
+	})
+	t.Run("it should return an error when the source is not a JSON object", func(t *testing.T) {
+		if _, err := ValidateJSON([]byte(`[1, 2]`), rules, Options{}); !errors.Is(err, ErrInvalidJSON) {
+			t.Errorf("Actual = %v, Expected = %v", err, ErrInvalidJSON)
+		}
+	})
+}
+
+func Test_FieldErrors(t *testing.T) {
+	schema := NewSchema(
+		DataObject{
+			"name": "ab",
+			"information": DataObject{
+				"role": "guest",
+			},
+		},
+		SchemaRules{
+			"name":  String{Min: 3},
+			"email": String{Required: true},
+			"information": Object{
+				Item: SchemaObject{
+					"role": String{In: []string{"admin", "member"}},
+				},
+			},
+		},
+		Options{},
+	)
+	bags, _ := schema.Validate()
+
+	cases := []struct {
+		key    string
+		rule   string
+		params map[string]any
+		value  any
+		path   []string
+	}{
+		{"name", RuleMin, map[string]any{"min": 3}, "ab", []string{"name"}},
+		{"email", RuleRequired, nil, nil, []string{"email"}},
+		{"information.role", RuleIn, map[string]any{"in": []string{"admin", "member"}}, "guest", []string{"information", "role"}},
+	}
+	for _, cs := range cases {
+		t.Run("it should record a structured error for "+cs.key, func(t *testing.T) {
+			fields := bags.Fields[cs.key]
+			if len(fields) != 1 {
+				t.Fatalf("Actual = %v, Expected one error", fields)
+			}
+			fe := fields[0]
+			if fe.Rule != cs.rule || !reflect.DeepEqual(fe.Params, cs.params) || fe.Value != cs.value || !reflect.DeepEqual(fe.Path, cs.path) {
+				t.Errorf("Actual = %+v, Expected rule = %s, params = %v, value = %v, path = %v", fe, cs.rule, cs.params, cs.value, cs.path)
+			}
+			if fe.Error() != bags.Errors[cs.key][0] {
+				t.Errorf("Actual = %v, Expected = %v", fe.Error(), bags.Errors[cs.key][0])
+			}
+		})
+	}
+}
+
+func Test_Deterministic_Order(t *testing.T) {
+	data := DataObject{
+		"items": []any{DataObject{"title": ""}, DataObject{"title": ""}},
+	}
+
+	t.Run("it should always report the same first failure with AbortEarly", func(t *testing.T) {
+		rules := SchemaRules{
+			"zip":   String{Required: true},
+			"name":  String{Required: true},
+			"email": String{Required: true},
+			"age":   Numeric[int]{Required: true},
+		}
+		for i := 0; i < 20; i++ {
+			schema := NewSchema(data, rules, Options{AbortEarly: true})
+			bags, _ := schema.Validate()
+			if !reflect.DeepEqual(bags.Keys, []string{"age"}) {
+				t.Fatalf("Actual = %v, Expected = %v", bags.Keys, []string{"age"})
+			}
+		}
+	})
+	t.Run("it should evaluate ordered schemas in declaration order", func(t *testing.T) {
+		schema := NewOrderedSchema(data, OrderedRules{
+			{"zip", String{Required: true}},
+			{"name", String{Required: true}},
+			{"items", SliceObject{Fields: OrderedRules{
+				{"title", String{Required: true}},
+				{"description", String{Required: true}},
+			}}},
+			{"email", String{Required: true}},
+		}, Options{})
+		bags, _ := schema.Validate()
+		expected := []string{"zip", "name", "items.0.title", "items.0.description", "items.1.title", "items.1.description", "email"}
+		if !reflect.DeepEqual(bags.Keys, expected) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Keys, expected)
+		}
+	})
+	t.Run("it should stop at the first failing slice object item with AbortEarly", func(t *testing.T) {
+		schema := NewSchema(data, SchemaRules{
+			"items": SliceObject{Item: SchemaObject{"title": String{Required: true}}},
+		}, Options{AbortEarly: true})
+		bags, _ := schema.Validate()
+		if !reflect.DeepEqual(bags.Keys, []string{"items.0.title"}) {
+			t.Errorf("Actual = %v, Expected = %v", bags.Keys, []string{"items.0.title"})
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package validet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// marshalYAML writes any JSON encodable value as block style YAML, keeping
+// the key order of its JSON encoding.
+func marshalYAML(value any) ([]byte, error) {
+	node, err := yamlNode(value)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// yamlNode converts a JSON encodable value into a YAML node. JSON being
+// YAML, the node is read from the JSON encoding, then switched to the block
+// style so the encoder only quotes the strings that need it.
+func yamlNode(value any) (*yaml.Node, error) {
+	source, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(source, &document); err != nil {
+		return nil, err
+	}
+	blockStyle(&document)
+	return document.Content[0], nil
+}
+
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+var yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// parseYAML reads the root node of a YAML (or JSON) document, reporting
+// syntax errors with their line.
+func parseYAML(source []byte) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(source, &document); err != nil {
+		return nil, yamlError(err)
+	}
+	if len(document.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}, nil
+	}
+	return document.Content[0], nil
+}
+
+// yamlError converts a yaml.v3 error into a DefinitionError, taking the
+// line out of the message of a syntax error or of the first error of a
+// *yaml.TypeError.
+func yamlError(err error) error {
+	message := err.Error()
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		message = typeErr.Errors[0]
+	}
+	if m := yamlErrorLine.FindStringSubmatch(message); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return &DefinitionError{Line: line, Err: errors.New(m[2])}
+	}
+	return &DefinitionError{Err: err}
+}
+
+// yamlResolve follows aliases to the node of their anchor.
+func yamlResolve(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// yamlPairs lists the keys and values of a mapping in order, expanding the
+// merge keys ("<<") of anchored mappings in place. Explicit keys win over
+// merged ones, and earlier merged keys over later ones.
+func yamlPairs(node *yaml.Node) ([][2]*yaml.Node, bool) {
+	node = yamlResolve(node)
+	if node.Kind != yaml.MappingNode {
+		return nil, false
+	}
+	type pair struct {
+		key, value *yaml.Node
+		explicit   bool
+	}
+	var all []pair
+	explicit := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := yamlResolve(node.Content[i]), yamlResolve(node.Content[i+1])
+		if key.Tag != "!!merge" {
+			all = append(all, pair{key, value, true})
+			explicit[key.Value] = true
+			continue
+		}
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, source := range sources {
+			merged, ok := yamlPairs(source)
+			if !ok {
+				return nil, false
+			}
+			for _, p := range merged {
+				all = append(all, pair{p[0], p[1], false})
+			}
+		}
+	}
+	pairs := make([][2]*yaml.Node, 0, len(all))
+	seen := map[string]bool{}
+	for _, p := range all {
+		if !p.explicit && (explicit[p.key.Value] || seen[p.key.Value]) {
+			continue
+		}
+		seen[p.key.Value] = true
+		pairs = append(pairs, [2]*yaml.Node{p.key, p.value})
+	}
+	return pairs, true
+}